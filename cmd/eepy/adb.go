@@ -0,0 +1,318 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// adbTimeout bounds each conversation with the adb server.
+const adbTimeout = 30 * time.Second
+
+// Errors for devices that cannot run commands. They are wrapped with the
+// message of the adb server, so callers can tell them apart with errors.Is.
+var (
+	errNoDevice     = translatedError("no Android device connected")
+	errUnauthorized = translatedError("device unauthorized; accept the USB debugging prompt on the device")
+	errOffline      = translatedError("device offline; reconnect it or restart adb")
+)
+
+// adbServerError is a FAIL reply of the adb server to a request.
+type adbServerError struct {
+	Request string
+	Message string
+}
+
+func (e *adbServerError) Error() string {
+	return tr.Sprintf("adb server refused %s: %s", e.Request, e.Message)
+}
+
+// Unwrap classifies the message of the adb server.
+func (e *adbServerError) Unwrap() error {
+	switch {
+	case strings.Contains(e.Message, "unauthorized"):
+		return errUnauthorized
+	case strings.Contains(e.Message, "offline"):
+		return errOffline
+	case strings.Contains(e.Message, "no devices"), strings.Contains(e.Message, "not found"):
+		return errNoDevice
+	}
+	return nil
+}
+
+// adbDevice is a device the adb server knows about. State is "device"
+// when it is ready, or "unauthorized", "offline" and so on.
+type adbDevice struct {
+	Serial string
+	State  string
+}
+
+// err returns why commands cannot run on the device, or nil if they can.
+func (d adbDevice) err() error {
+	switch d.State {
+	case "device":
+		return nil
+	case "unauthorized":
+		return tr.Errorf("%s: %w", d.Serial, errUnauthorized)
+	case "offline":
+		return tr.Errorf("%s: %w", d.Serial, errOffline)
+	}
+	return tr.Errorf("%s: device is %s", d.Serial, d.State)
+}
+
+// adbClient talks to the adb server with its host protocol, so eepy does
+// not need the adb binary. The server itself is started by adb, by Android
+// Studio or with `adb start-server`.
+type adbClient struct {
+	addr string
+}
+
+// newADBClient returns a client for the adb server on localhost, at the
+// port in ANDROID_ADB_SERVER_PORT or 5037.
+func newADBClient(getenv func(string) string) *adbClient {
+	port := "5037"
+	if getenv != nil && getenv("ANDROID_ADB_SERVER_PORT") != "" {
+		port = getenv("ANDROID_ADB_SERVER_PORT")
+	}
+	return &adbClient{addr: net.JoinHostPort("localhost", port)}
+}
+
+// dial opens a connection to the adb server. Each connection serves one
+// host request, or switches to a device and serves one device request.
+func (c *adbClient) dial() (*bufio.ReadWriter, net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", c.addr, adbTimeout)
+	if err != nil {
+		return nil, nil, tr.Errorf("cannot reach the adb server at %s; start it with 'adb start-server': %w", c.addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(adbTimeout))
+	return bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn)), conn, nil
+}
+
+// send writes request with its length as four hex digits and reads the
+// OKAY or FAIL status of the reply.
+func (c *adbClient) send(rw *bufio.ReadWriter, request string) error {
+	if _, err := fmt.Fprintf(rw, "%04x%s", len(request), request); err != nil {
+		return err
+	}
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+	status := make([]byte, 4)
+	if _, err := io.ReadFull(rw, status); err != nil {
+		return tr.Errorf("error reading adb reply to %s: %w", request, err)
+	}
+	switch string(status) {
+	case "OKAY":
+		return nil
+	case "FAIL":
+		message, err := readHexString(rw)
+		if err != nil {
+			return tr.Errorf("error reading adb reply to %s: %w", request, err)
+		}
+		return &adbServerError{Request: request, Message: message}
+	}
+	return tr.Errorf("unexpected adb reply %q to %s", status, request)
+}
+
+// readHexString reads a string preceded by its length as four hex digits.
+func readHexString(r io.Reader) (string, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", err
+	}
+	n, err := strconv.ParseUint(string(header), 16, 16)
+	if err != nil {
+		return "", tr.Errorf("invalid length %q: %w", header, err)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Devices lists the devices the adb server knows about.
+func (c *adbClient) Devices() ([]adbDevice, error) {
+	rw, conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := c.send(rw, "host:devices"); err != nil {
+		return nil, err
+	}
+	list, err := readHexString(rw)
+	if err != nil {
+		return nil, tr.Errorf("error reading device list: %w", err)
+	}
+	var devices []adbDevice
+	for _, line := range strings.Split(list, "\n") {
+		serial, state, ok := strings.Cut(line, "\t")
+		if ok {
+			devices = append(devices, adbDevice{Serial: serial, State: strings.TrimSpace(state)})
+		}
+	}
+	return devices, nil
+}
+
+// Shell runs command in a shell on the device with the given serial, or
+// on the only device if serial is empty, and returns its output.
+func (c *adbClient) Shell(serial, command string) (string, error) {
+	rw, conn, err := c.dial()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	transport := "host:transport-any"
+	if serial != "" {
+		transport = "host:transport:" + serial
+	}
+	if err := c.send(rw, transport); err != nil {
+		return "", err
+	}
+	if err := c.send(rw, "shell:"+command); err != nil {
+		return "", err
+	}
+	output, err := io.ReadAll(rw)
+	if err != nil {
+		return "", tr.Errorf("error reading output of %q: %w", command, err)
+	}
+	return strings.ReplaceAll(string(output), "\r\n", "\n"), nil
+}
+
+// deviceName matches the serials adb reports, like R58M123, emulator-5554
+// or 192.168.1.5:5555, and the names of device aliases.
+var deviceName = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// checkDevices checks devices separated by commas, given by serial or
+// alias, or as all.
+func checkDevices(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if strings.Contains(name, "=") {
+			return tr.Errorf("invalid device %q: set aliases with device-aliases", name)
+		}
+		if !deviceName.MatchString(name) {
+			return tr.Errorf("invalid device %q: use a serial like R58M123, an alias or all", name)
+		}
+	}
+	return nil
+}
+
+// parseAliases parses device aliases written as name=serial pairs separated
+// by commas.
+func parseAliases(s string) (map[string]string, error) {
+	aliases := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, serial, ok := strings.Cut(pair, "=")
+		name, serial = strings.TrimSpace(name), strings.TrimSpace(serial)
+		if !ok || !deviceName.MatchString(name) || !deviceName.MatchString(serial) {
+			return nil, tr.Errorf("error parsing device alias %q: want name=serial", pair)
+		}
+		if name == "all" {
+			return nil, tr.Errorf("error parsing device aliases: all cannot be an alias")
+		}
+		aliases[name] = serial
+	}
+	return aliases, nil
+}
+
+// aliasOf returns the alias of the device with the given serial, if any.
+func aliasOf(aliases map[string]string, serial string) string {
+	for _, name := range slices.Sorted(maps.Keys(aliases)) {
+		if aliases[name] == serial {
+			return name
+		}
+	}
+	return ""
+}
+
+// chooseDevices picks the devices with the serials in requested from those
+// the adb server knows about. "all" picks every device. Without any request
+// the only device is picked, since guessing between several could wake the
+// wrong person.
+func chooseDevices(requested []string, available []adbDevice) ([]adbDevice, error) {
+	if len(available) == 0 {
+		return nil, errNoDevice
+	}
+	if len(requested) == 0 {
+		if len(available) > 1 {
+			var serials []string
+			for _, d := range available {
+				serials = append(serials, d.Serial)
+			}
+			return nil, tr.Errorf("%d devices are connected (%s); choose with --device, or --device all", len(available), strings.Join(serials, ", "))
+		}
+		return available, nil
+	}
+	var chosen []adbDevice
+	for _, serial := range requested {
+		if serial == "all" {
+			return available, nil
+		}
+		i := slices.IndexFunc(available, func(d adbDevice) bool { return d.Serial == serial })
+		if i < 0 {
+			return nil, tr.Errorf("device %s is not connected: %w", serial, errNoDevice)
+		}
+		if !slices.Contains(chosen, available[i]) {
+			chosen = append(chosen, available[i])
+		}
+	}
+	return chosen, nil
+}
+
+// DeviceOutput is a device in the output of alarms devices.
+type DeviceOutput struct {
+	Serial string `json:"serial"`
+	State  string `json:"state"`
+	Alias  string `json:"alias,omitempty"`
+}
+
+func alarmsDevicesCommand(flags *pflag.FlagSet) runFunc {
+	output := outputFlag(flags)
+	return func(a *app, args []string) error {
+		aliases, err := a.deviceAliases()
+		if err != nil {
+			return err
+		}
+		devices, err := newADBClient(a.getenv).Devices()
+		if err != nil {
+			return err
+		}
+		list := []DeviceOutput{}
+		for _, d := range devices {
+			list = append(list, DeviceOutput{Serial: d.Serial, State: d.State, Alias: aliasOf(aliases, d.Serial)})
+		}
+		return writeOutput(a.stdout, *output, list, func(w io.Writer) {
+			if len(list) == 0 {
+				tr.Fprintln(w, "No devices connected.")
+				return
+			}
+			for _, d := range list {
+				fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("%-20s %-12s %s", d.Serial, d.State, d.Alias), " "))
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeADBServer answers the host protocol of the adb server on a local
+// port, and records the requests it gets.
+type fakeADBServer struct {
+	addr    string
+	devices string
+	fail    map[string]string
+	shell   func(serial, command string) string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func startFakeADBServer(t *testing.T, devices string) *fakeADBServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	s := &fakeADBServer{
+		addr:    listener.Addr().String(),
+		devices: devices,
+		fail:    map[string]string{},
+		shell:   func(serial, command string) string { return "" },
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeADBServer) serve(conn net.Conn) {
+	defer conn.Close()
+	serial := ""
+	for {
+		request, err := readHexString(conn)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, request)
+		message, fail := s.fail[request]
+		s.mu.Unlock()
+		if fail {
+			fmt.Fprintf(conn, "FAIL%04x%s", len(message), message)
+			return
+		}
+		switch {
+		case request == "host:devices":
+			fmt.Fprintf(conn, "OKAY%04x%s", len(s.devices), s.devices)
+			return
+		case request == "host:transport-any":
+			serial, _, _ = strings.Cut(s.devices, "\t")
+			fmt.Fprint(conn, "OKAY")
+		case strings.HasPrefix(request, "host:transport:"):
+			serial = strings.TrimPrefix(request, "host:transport:")
+			fmt.Fprint(conn, "OKAY")
+		case strings.HasPrefix(request, "shell:"):
+			fmt.Fprint(conn, "OKAY"+s.shell(serial, strings.TrimPrefix(request, "shell:")))
+			return
+		default:
+			fmt.Fprintf(conn, "FAIL%04x%s", len("unknown service"), "unknown service")
+			return
+		}
+	}
+}
+
+func (s *fakeADBServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func TestADBDevices(t *testing.T) {
+	server := startFakeADBServer(t, "R58M123\tdevice\nemulator-5554\tunauthorized\n")
+	devices, err := (&adbClient{addr: server.addr}).Devices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []adbDevice{{"R58M123", "device"}, {"emulator-5554", "unauthorized"}}
+	if !slices.Equal(devices, want) {
+		t.Errorf("Expected %v, got %v", want, devices)
+	}
+	if devices[0].err() != nil || !errors.Is(devices[1].err(), errUnauthorized) {
+		t.Errorf("Unexpected device errors %v, %v", devices[0].err(), devices[1].err())
+	}
+}
+
+func TestADBShell(t *testing.T) {
+	server := startFakeADBServer(t, "R58M123\tdevice\n")
+	server.shell = func(serial, command string) string { return serial + " ran " + command + "\r\n" }
+	client := &adbClient{addr: server.addr}
+
+	output, err := client.Shell("", "echo hi")
+	if err != nil || output != "R58M123 ran echo hi\n" {
+		t.Errorf("Unexpected output %q, %v", output, err)
+	}
+	if _, err := client.Shell("other", "true"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"host:transport-any", "shell:echo hi", "host:transport:other", "shell:true"}
+	if got := server.received(); !slices.Equal(got, want) {
+		t.Errorf("Expected requests %q, got %q", want, got)
+	}
+}
+
+func TestADBErrors(t *testing.T) {
+	server := startFakeADBServer(t, "")
+	client := &adbClient{addr: server.addr}
+	tests := []struct {
+		message string
+		want    error
+	}{
+		{"no devices/emulators found", errNoDevice},
+		{"device 'R58M123' not found", errNoDevice},
+		{"device unauthorized.\nThis adb server's $ADB_VENDOR_KEYS is not set", errUnauthorized},
+		{"device offline", errOffline},
+	}
+	for _, test := range tests {
+		server.fail["host:transport-any"] = test.message
+		_, err := client.Shell("", "true")
+		var serverErr *adbServerError
+		if !errors.Is(err, test.want) || !errors.As(err, &serverErr) || serverErr.Message != test.message {
+			t.Errorf("%q: expected %v, got %v", test.message, test.want, err)
+		}
+	}
+
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+	if _, err := (&adbClient{addr: addr}).Devices(); err == nil || !strings.Contains(err.Error(), "adb start-server") {
+		t.Errorf("Expected a hint to start the adb server, got %v", err)
+	}
+}
+
+func TestADBBackend(t *testing.T) {
+	server := startFakeADBServer(t, "R58M123\tdevice\n")
+	server.shell = func(serial, command string) string {
+		return "Starting: Intent { act=android.intent.action.SET_ALARM }\nStatus: ok\n"
+	}
+	client := &adbClient{addr: server.addr}
+
+	var out bytes.Buffer
+	p := testPlan(t)
+	if result := setAlarms(&out, newADBBackend(client, "R58M123"), p.id(), planAlarms(p, p.Days(), alarmOptions{}), nil); len(result.Created) != 3 {
+		t.Errorf("Expected 3 alarms to be created, got %+v", result)
+	}
+	var shells []string
+	for _, request := range server.received() {
+		if strings.HasPrefix(request, "shell:am start -W -a android.intent.action.SET_ALARM") {
+			shells = append(shells, request)
+		}
+	}
+	if len(shells) != 3 || strings.Contains(out.String(), "Error") {
+		t.Errorf("Expected 3 alarms to be set, got %d:\n%s", len(shells), out.String())
+	}
+}
+
+func TestNewADBClient(t *testing.T) {
+	if addr := newADBClient(nil).addr; addr != "localhost:5037" {
+		t.Errorf("Expected the default adb server address, got %s", addr)
+	}
+	if addr := newADBClient(func(string) string { return "5038" }).addr; addr != "localhost:5038" {
+		t.Errorf("Expected ANDROID_ADB_SERVER_PORT to be used, got %s", addr)
+	}
+}
+
+func TestParseAliases(t *testing.T) {
+	aliases, err := parseAliases("phone=R58M123, tablet = emulator-5554,")
+	if err != nil || len(aliases) != 2 || aliases["phone"] != "R58M123" || aliases["tablet"] != "emulator-5554" {
+		t.Errorf("Unexpected aliases %v, %v", aliases, err)
+	}
+	for _, bad := range []string{"phone", "=R58M123", "all=R58M123"} {
+		if _, err := parseAliases(bad); err == nil {
+			t.Errorf("Expected %q to be refused", bad)
+		}
+	}
+}
+
+func TestChooseDevices(t *testing.T) {
+	one := []adbDevice{{"R58M123", "device"}}
+	two := []adbDevice{{"R58M123", "device"}, {"emulator-5554", "device"}}
+	tests := []struct {
+		requested []string
+		available []adbDevice
+		want      []string
+		err       string
+	}{
+		{nil, one, []string{"R58M123"}, ""},
+		{nil, nil, nil, "no Android device"},
+		{nil, two, nil, "2 devices are connected (R58M123, emulator-5554)"},
+		{[]string{"emulator-5554"}, two, []string{"emulator-5554"}, ""},
+		{[]string{"emulator-5554", "R58M123", "emulator-5554"}, two, []string{"emulator-5554", "R58M123"}, ""},
+		{[]string{"all"}, two, []string{"R58M123", "emulator-5554"}, ""},
+		{[]string{"pixel"}, two, nil, "device pixel is not connected"},
+	}
+	for _, test := range tests {
+		chosen, err := chooseDevices(test.requested, test.available)
+		var serials []string
+		for _, d := range chosen {
+			serials = append(serials, d.Serial)
+		}
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error containing %q, got %v", test.requested, test.err, err)
+			}
+		} else if err != nil || !slices.Equal(serials, test.want) {
+			t.Errorf("%q: expected %q, got %q, %v", test.requested, test.want, serials, err)
+		}
+	}
+}
+
+func TestAlarmsOnSeveralDevices(t *testing.T) {
+	setConfigDir(t.TempDir())
+	server := startFakeADBServer(t, "R58M123\tdevice\nemulator-5554\tdevice\nZY22\tunauthorized\n")
+	server.fail["host:transport:ZY22"] = "device unauthorized."
+	_, port, _ := net.SplitHostPort(server.addr)
+	t.Setenv("ANDROID_ADB_SERVER_PORT", port)
+	timeNow = func() time.Time { return time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-01-01")
+	runTest(t, "", "config", "set", "device-aliases", "phone=R58M123,tablet=emulator-5554")
+
+	code, stdout, _ := runTest(t, "", "alarms", "devices")
+	if code != 0 || !strings.Contains(stdout, "R58M123              device       phone\n") || !strings.Contains(stdout, "ZY22                 unauthorized") {
+		t.Errorf("Unexpected device list, got %d:\n%s", code, stdout)
+	}
+
+	if code, _, stderr := runTest(t, "", "alarms", "sync"); code != 1 || !strings.Contains(stderr, "3 devices are connected") {
+		t.Errorf("Expected a device to be asked for, got %d: %s", code, stderr)
+	}
+
+	code, stdout, _ = runTest(t, "", "alarms", "sync", "--device", "phone", "--device", "tablet")
+	if code != 0 || !strings.Contains(stdout, "ADB R58M123: 3 alarms set, 0 dismissed, 0 failed") || !strings.Contains(stdout, "ADB emulator-5554: 3 alarms set, 0 dismissed, 0 failed") {
+		t.Errorf("Expected alarms on both aliased devices, got %d:\n%s", code, stdout)
+	}
+	if got := server.received(); !slices.Contains(got, "host:transport:emulator-5554") {
+		t.Errorf("Expected the tablet to be used, got requests %q", got)
+	}
+
+	runTest(t, "", "config", "set", "device", "all")
+	code, stdout, stderr := runTest(t, "", "alarms", "sync")
+	if code != 1 || !strings.Contains(stdout, "ADB ZY22: not reachable") || !strings.Contains(stderr, "ADB ZY22") {
+		t.Errorf("Expected the unauthorized device to fail the sync, got %d:\n%s%s", code, stdout, stderr)
+	}
+}
+
+func TestAlarmsVerify(t *testing.T) {
+	setConfigDir(t.TempDir())
+	dumpsys, err := os.ReadFile("testdata/dumpsys-alarm-android14.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := startFakeADBServer(t, "R58M123\tdevice\n")
+	server.shell = func(serial, command string) string {
+		if command == "dumpsys alarm" {
+			return string(dumpsys)
+		}
+		return ""
+	}
+	_, port, _ := net.SplitHostPort(server.addr)
+	t.Setenv("ANDROID_ADB_SERVER_PORT", port)
+	timeNow = func() time.Time { return time.Date(2025, 7, 13, 21, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:00", "--adjustment", "1h", "--start-date", "2025-07-13")
+	// The alarm on Jul 18 is not one eepy set.
+	code, stdout, stderr := runTest(t, "", "alarms", "verify")
+	if code != 0 || strings.Contains(stdout, "Extra") {
+		t.Errorf("Expected the user's own alarm to be left out, got %d:\n%s%s", code, stdout, stderr)
+	}
+
+	target := alarmTarget{Backend: "adb", Device: "R58M123"}
+	stale := Alarm{Time: time.Date(2025, 7, 18, 6, 15, 0, 0, time.UTC), Label: "Sleep Adjustment Wake Up: Fri, Jul 18"}
+	if err := saveCreatedAlarms([]CreatedAlarm{{Target: target, Plan: "old", Alarm: stale}}); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr = runTest(t, "", "alarms", "verify")
+	if code != 1 || !strings.Contains(stdout, "  Extra:      Fri, Jul 18 06:15\n") || strings.Contains(stdout, "Missing") || !strings.Contains(stderr, "eepy alarms sync") {
+		t.Errorf("Expected the extra alarm to be reported, got %d:\n%s%s", code, stdout, stderr)
+	}
+
+	// Jul 18 is after the verified day.
+	code, stdout, _ = runTest(t, "", "alarms", "verify", "--alarm-days", "1", "-o", "json")
+	if code != 0 || !strings.Contains(stdout, `"backend": "ADB R58M123"`) || !strings.Contains(stdout, `"extra": []`) {
+		t.Errorf("Unexpected JSON report, got %d:\n%s", code, stdout)
+	}
+}
@@ -0,0 +1,894 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Alarm is a wake up alarm or bedtime reminder for one day of a plan.
+// Backends that cannot honour the details below set the alarm without them.
+type Alarm struct {
+	Time  time.Time `json:"time"`
+	Label string    `json:"label"`
+	// Key tells apart the alarms eepy sets without the label, which
+	// changes with the language and clock: it is the kind of alarm and
+	// the label template. Alarms recorded without one go by label.
+	Key string `json:"key,omitempty"`
+	// OneShot alarms ring once instead of every week on their weekday.
+	OneShot  bool   `json:"one_shot,omitempty"`
+	Vibrate  bool   `json:"vibrate,omitempty"`
+	Ringtone string `json:"ringtone,omitempty"`
+	// SkipUI sets the alarm without showing the clock app.
+	SkipUI bool `json:"-"`
+	// Reminder marks a bedtime reminder, which counts down to Time as a
+	// timer instead of ringing when the clock shows it.
+	Reminder bool `json:"reminder,omitempty"`
+}
+
+// alarmBackend sets alarms somewhere they will wake you, such as a phone.
+// Planning code only talks to this interface, so a backend can be added
+// without touching it.
+type alarmBackend interface {
+	// Name is how messages refer to the backend, like "ADB R58M123".
+	Name() string
+	// Target identifies where the alarms go, so that they can be found
+	// again later.
+	Target() alarmTarget
+	Create(alarm Alarm) error
+	// List returns the alarms that are set, without labels if the backend
+	// cannot read them. Backends that cannot read alarms back at all return
+	// an error wrapping errors.ErrUnsupported.
+	List() ([]Alarm, error)
+	Delete(alarm Alarm) error
+}
+
+// alarmBackends opens the alarm backends by the name the alarm-backend
+// setting uses. Backends that reach several devices return one backend for
+// each of the requested devices.
+var alarmBackends = map[string]func(a *app, devices []string) ([]alarmBackend, error){
+	"adb":    newADBBackends,
+	"termux": newTermuxBackends,
+}
+
+func checkAlarmBackend(name string) error {
+	if _, ok := alarmBackends[name]; !ok {
+		return tr.Errorf("unknown alarm backend %q; choose one of %s", name, strings.Join(slices.Sorted(maps.Keys(alarmBackends)), ", "))
+	}
+	return nil
+}
+
+// deviceAliases returns the device names of the device-aliases setting.
+func (a *app) deviceAliases() (map[string]string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, tr.Errorf("error loading config: %w", err)
+	}
+	value, _ := lookupSetting("device-aliases", a.getenv, cfg)
+	return parseAliases(value)
+}
+
+// deviceSerials replaces the device aliases in names by their serials.
+func (a *app) deviceSerials(names []string) ([]string, error) {
+	aliases, err := a.deviceAliases()
+	if err != nil {
+		return nil, err
+	}
+	var serials []string
+	for _, name := range names {
+		if serial, ok := aliases[name]; ok {
+			name = serial
+		}
+		serials = append(serials, name)
+	}
+	return serials, nil
+}
+
+// maxAlarmDays is the most days alarms can be set ahead. Android alarms
+// repeat on a weekday, so a second week would need the same alarms again.
+const maxAlarmDays = 7
+
+// alarmOptions are the choices of which alarms to set for a plan, and how.
+type alarmOptions struct {
+	// NoSkipToday sets an alarm for today too.
+	NoSkipToday bool
+	// Days is how many days ahead alarms are kept set.
+	Days int
+	// Label is the template of alarm labels; see alarmLabel.
+	Label string
+	// PreAlarm and Backup add alarms this long before and after each
+	// wake up alarm, unless they are zero.
+	PreAlarm time.Duration
+	Backup   time.Duration
+	OneShot  bool
+	Vibrate  bool
+	Ringtone string
+	SkipUI   bool
+	// BedtimeReminder adds a reminder at each bedtime, and WindDown
+	// reminders this long before it.
+	BedtimeReminder bool
+	WindDown        []time.Duration
+	// Notify posts a summary on the device, if the backend can.
+	Notify bool
+}
+
+// check reports options that cannot be used.
+func (o alarmOptions) check() error {
+	if err := checkAlarmDays(strconv.Itoa(o.Days)); err != nil {
+		return err
+	}
+	if err := checkAlarmLabel(o.Label); err != nil {
+		return err
+	}
+	if err := checkRingtone(o.Ringtone); err != nil {
+		return err
+	}
+	if err := checkAlarmOffset(o.PreAlarm.String()); err != nil {
+		return tr.Errorf("invalid pre-alarm: %w", err)
+	}
+	if err := checkAlarmOffset(o.Backup.String()); err != nil {
+		return tr.Errorf("invalid backup-alarm: %w", err)
+	}
+	for _, d := range o.WindDown {
+		if err := checkWindDown(d.String()); err != nil {
+			return tr.Errorf("invalid wind-down: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkAlarmOffset checks how long before or after the wake up alarm an
+// extra alarm rings.
+func checkAlarmOffset(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if d < 0 || d > 2*time.Hour {
+		return tr.Errorf("%s is not between 0 and 2h", s)
+	}
+	return nil
+}
+
+// maxWindDown is how long before bedtime a wind-down reminder can be.
+const maxWindDown = 3 * time.Hour
+
+// checkWindDown checks durations before bedtime separated by commas.
+func checkWindDown(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, field := range strings.Split(s, ",") {
+		d, err := time.ParseDuration(field)
+		if err != nil {
+			return err
+		}
+		if d <= 0 || d > maxWindDown {
+			return tr.Errorf("%s is not between 0 and %s", field, shortDuration(maxWindDown))
+		}
+	}
+	return nil
+}
+
+// labelPlaceholders are what the alarm-label setting can contain.
+var labelPlaceholders = []string{"{day}", "{time}", "{number}", "{days}", "{target}"}
+
+func checkAlarmLabel(template string) error {
+	if template == "" {
+		return nil
+	}
+	for _, placeholder := range regexp.MustCompile(`\{[^{}]*\}`).FindAllString(template, -1) {
+		if !slices.Contains(labelPlaceholders, placeholder) {
+			return tr.Errorf("unknown placeholder %s; use %s", placeholder, strings.Join(labelPlaceholders, ", "))
+		}
+	}
+	// Alarms are dismissed by label, so each day needs its own.
+	if !strings.Contains(template, "{day}") && !strings.Contains(template, "{number}") {
+		return tr.Errorf("the label needs {day} or {number} to tell the days apart")
+	}
+	return nil
+}
+
+// checkRingtone checks that a ringtone is silent or a URI like the clock
+// app takes.
+func checkRingtone(s string) error {
+	if s == "" || s == "silent" {
+		return nil
+	}
+	if u, err := url.Parse(s); err != nil || u.Scheme == "" {
+		return tr.Errorf("invalid ringtone %q: use silent or a URI like content://media/internal/audio/media/42", s)
+	}
+	return nil
+}
+
+func checkAlarmDays(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > maxAlarmDays {
+		return tr.Errorf("alarm days must be between 1 and %d, since alarms repeat weekly", maxAlarmDays)
+	}
+	return nil
+}
+
+// alarmFlags holds the flags that choose where and which alarms are set.
+type alarmFlags struct {
+	backend string
+	devices []string
+	options alarmOptions
+}
+
+// newAlarmFlags defines the alarm flags, which default to the settings of
+// the same names.
+func newAlarmFlags(flags *pflag.FlagSet) *alarmFlags {
+	f := &alarmFlags{}
+	flags.StringVar(&f.backend, "alarm-backend", "", "Where to set alarms: adb or termux (default: termux in Termux, adb elsewhere)")
+	flags.StringSliceVar(&f.devices, "device", nil, "Serial or alias of the device to set alarms on; repeat it or pass 'all' for several")
+	flags.BoolVar(&f.options.NoSkipToday, "no-skip-today", false, "Do not skip setting an alarm for today")
+	flags.IntVar(&f.options.Days, "alarm-days", maxAlarmDays, "How many days ahead to keep alarms set (1-7)")
+	flags.StringVar(&f.options.Label, "alarm-label", "", "Label template, with {day}, {time}, {number}, {days} and {target}")
+	flags.DurationVar(&f.options.PreAlarm, "pre-alarm", 0, "Also set an alarm this long before each wake up alarm")
+	flags.DurationVar(&f.options.Backup, "backup-alarm", 0, "Also set an alarm this long after each wake up alarm")
+	flags.BoolVar(&f.options.OneShot, "one-shot", false, "Set alarms that ring once instead of every week; only the next day's can be set")
+	flags.BoolVar(&f.options.Vibrate, "vibrate", true, "Vibrate when the alarm rings")
+	flags.StringVar(&f.options.Ringtone, "ringtone", "", "Ringtone URI, or silent (default: the clock app's)")
+	flags.BoolVar(&f.options.SkipUI, "skip-ui", false, "Set alarms without showing the clock app")
+	flags.BoolVar(&f.options.BedtimeReminder, "bedtime-reminder", false, "Also remind of each bedtime with a timer")
+	flags.DurationSliceVar(&f.options.WindDown, "wind-down", nil, "Also remind this long before each bedtime, like 1h,30m")
+	flags.BoolVar(&f.options.Notify, "notify", false, "Post a notification on the device about the alarms set, if the backend can")
+	configurable(flags, "alarm-backend", "device", "no-skip-today", "alarm-days", "alarm-label", "pre-alarm", "backup-alarm", "one-shot", "vibrate", "ringtone", "skip-ui", "bedtime-reminder", "wind-down", "notify")
+	return f
+}
+
+// open opens the chosen backends. Device aliases are replaced by their
+// serials before the backend sees them.
+func (f *alarmFlags) open(a *app) ([]alarmBackend, error) {
+	if f.backend == "" {
+		f.backend = defaultAlarmBackend(a.getenv)
+	}
+	if err := checkAlarmBackend(f.backend); err != nil {
+		return nil, err
+	}
+	if err := f.options.check(); err != nil {
+		return nil, err
+	}
+	serials, err := a.deviceSerials(f.devices)
+	if err != nil {
+		return nil, err
+	}
+	return alarmBackends[f.backend](a, serials)
+}
+
+// sync syncs the alarms of p with the chosen backends.
+func (f *alarmFlags) sync(a *app, p *Plan) error {
+	backends, err := f.open(a)
+	if err != nil {
+		return err
+	}
+	return syncAlarms(a.stdout, backends, p, f.options)
+}
+
+// alarmLabel is the label of the alarm for day d of p. The placeholders of
+// template are replaced by the date, the wake up time, the day number, the
+// number of days and the target wake up time. An empty template gives the
+// built-in label.
+func alarmLabel(template string, p *Plan, d Day) string {
+	if template == "" {
+		return tr.Sprintf("Sleep Adjustment Wake Up: %s", tr.day(d.Wake))
+	}
+	return strings.NewReplacer(
+		"{day}", tr.day(d.Wake),
+		"{time}", tr.clock(d.Wake),
+		"{number}", strconv.Itoa(d.Number),
+		"{days}", strconv.Itoa(len(p.Days())),
+		"{target}", tr.clock(p.TargetWakeTime),
+	).Replace(template)
+}
+
+// AlarmReport compares the alarms a backend has with the alarms it should
+// have.
+type AlarmReport struct {
+	Backend    string          `json:"backend"`
+	Missing    []Alarm         `json:"missing"`
+	Extra      []Alarm         `json:"extra"`
+	Mismatched []AlarmMismatch `json:"mismatched"`
+}
+
+// AlarmMismatch is an alarm that is set on the right day, but not as
+// planned.
+type AlarmMismatch struct {
+	Want Alarm `json:"want"`
+	Have Alarm `json:"have"`
+}
+
+// OK reports whether the backend has exactly the alarms it should have.
+func (r AlarmReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+// verifyAlarms lists the alarms of b and reports which of want are missing
+// or set at another time on their day, and which alarms eepy owns are set
+// that are not in want. Alarms listed without a label are matched by time
+// alone.
+func verifyAlarms(b alarmBackend, want []Alarm, owned func(Alarm) bool) (AlarmReport, error) {
+	have, err := b.List()
+	if err != nil {
+		return AlarmReport{}, err
+	}
+	sameLabel := func(w, h Alarm) bool { return h.Label == "" || h.Label == w.Label }
+	report := AlarmReport{Backend: b.Name(), Missing: []Alarm{}, Extra: []Alarm{}, Mismatched: []AlarmMismatch{}}
+	var missing []Alarm
+	for _, w := range want {
+		i := slices.IndexFunc(have, func(h Alarm) bool { return sameLabel(w, h) && w.Time.Equal(h.Time) })
+		if i < 0 {
+			missing = append(missing, w)
+			continue
+		}
+		have = slices.Delete(have, i, i+1)
+	}
+	for _, w := range missing {
+		i := slices.IndexFunc(have, func(h Alarm) bool { return sameLabel(w, h) && sameDate(w.Time, h.Time) })
+		if i < 0 {
+			report.Missing = append(report.Missing, w)
+			continue
+		}
+		report.Mismatched = append(report.Mismatched, AlarmMismatch{Want: w, Have: have[i]})
+		have = slices.Delete(have, i, i+1)
+	}
+	for _, h := range have {
+		if owned(h) {
+			report.Extra = append(report.Extra, h)
+		}
+	}
+	return report, nil
+}
+
+// ownedAlarm returns whether an alarm listed on a device is one eepy set
+// for the verified dates, from first up to end: one recorded in created,
+// or one labelled like the alarms in want. The user's own alarms and
+// alarms for other dates are left out, since syncing would not touch them.
+func ownedAlarm(created []CreatedAlarm, first, end time.Time, want []Alarm) func(Alarm) bool {
+	return func(h Alarm) bool {
+		if date := dateOf(wallClock(h.Time, first.Location())); date.Before(first) || !date.Before(end) {
+			return false
+		}
+		if h.Label != "" && slices.ContainsFunc(want, func(w Alarm) bool { return w.Label == h.Label }) {
+			return true
+		}
+		return slices.ContainsFunc(created, func(c CreatedAlarm) bool {
+			return (h.Label == "" || h.Label == c.Label) && c.Time.Equal(h.Time)
+		})
+	}
+}
+
+func writeAlarmReport(w io.Writer, r AlarmReport) {
+	if r.OK() {
+		tr.Fprintf(w, "%s: the alarms match the plan.\n", r.Backend)
+		return
+	}
+	at := func(t time.Time) string { return tr.day(t) + " " + tr.clock(t) }
+	fmt.Fprintf(w, "%s:\n", r.Backend)
+	for _, alarm := range r.Missing {
+		tr.Fprintf(w, "  Missing:    %s\n", at(alarm.Time))
+	}
+	for _, m := range r.Mismatched {
+		tr.Fprintf(w, "  Mismatched: %s is set for %s\n", at(m.Want.Time), tr.clock(m.Have.Time))
+	}
+	for _, alarm := range r.Extra {
+		tr.Fprintf(w, "  Extra:      %s\n", at(alarm.Time))
+	}
+}
+
+func alarmsVerifyCommand(flags *pflag.FlagSet) runFunc {
+	alarms := newAlarmFlags(flags)
+	output := outputFlag(flags)
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		backends, err := alarms.open(a)
+		if err != nil {
+			return err
+		}
+		// Only the next time of an alarm is scheduled, so alarms that
+		// have rung are not expected.
+		now := wallClock(timeNow(), p.StartDate.Location())
+		first, end := alarmWindow(now, alarms.options)
+		want := slices.DeleteFunc(planAlarms(p, alarmDays(p, now, alarms.options), alarms.options), func(alarm Alarm) bool {
+			return !alarm.Time.After(now)
+		})
+		created, err := loadCreatedAlarms()
+		if err != nil {
+			return err
+		}
+		reports := []AlarmReport{}
+		ok := true
+		for _, b := range backends {
+			var recorded []CreatedAlarm
+			for _, c := range created {
+				if c.Target == b.Target() {
+					recorded = append(recorded, c)
+				}
+			}
+			report, err := verifyAlarms(b, want, ownedAlarm(recorded, first, end, want))
+			if err != nil {
+				return tr.Errorf("error reading alarms via %s: %w", b.Name(), err)
+			}
+			reports = append(reports, report)
+			ok = ok && report.OK()
+		}
+		if err := writeOutput(a.stdout, *output, reports, func(w io.Writer) {
+			for _, r := range reports {
+				writeAlarmReport(w, r)
+			}
+		}); err != nil {
+			return err
+		}
+		if !ok {
+			return tr.Errorf("the alarms do not match the plan; run 'eepy alarms sync' to set them again")
+		}
+		return nil
+	}
+}
+
+// alarmResult is how syncing the alarms of a plan with one backend went.
+// Err is set if the backend could not be reached at all.
+type alarmResult struct {
+	Backend   string
+	Created   []Alarm
+	Dismissed []CreatedAlarm
+	Failed    int
+	Err       error
+}
+
+// deviceUnavailable reports whether err means that no alarm can be set on
+// the device, so there is no point in trying the others.
+func deviceUnavailable(err error) bool {
+	return errors.Is(err, errNoDevice) || errors.Is(err, errUnauthorized) || errors.Is(err, errOffline)
+}
+
+// sameAlarm reports whether x and y are the same alarm, set with the same
+// options, so that an alarm whose options changed is replaced.
+func sameAlarm(x, y Alarm) bool {
+	if x.OneShot != y.OneShot || x.Vibrate != y.Vibrate || x.Ringtone != y.Ringtone || x.Reminder != y.Reminder {
+		return false
+	}
+	if x.Key == "" || y.Key == "" {
+		return x.Label == y.Label && x.Time.Equal(y.Time)
+	}
+	return x.Key == y.Key && x.Time.Equal(y.Time)
+}
+
+// alarmWindow returns the first date that should have an alarm at now, and
+// the date after the last; see alarmDays.
+func alarmWindow(now time.Time, options alarmOptions) (first, end time.Time) {
+	first = dateOf(now)
+	if !options.NoSkipToday {
+		first = first.AddDate(0, 0, 1)
+	}
+	end = first.AddDate(0, 0, options.Days)
+	if options.OneShot {
+		first, end = dateOf(now), dateOf(now).AddDate(0, 0, 2)
+	}
+	return first, end
+}
+
+// alarmDays returns the days of p that should have an alarm at now: those
+// in the next days days, starting today or, unless noSkipToday is set,
+// tomorrow. Running again later moves the window forward. One-shot alarms
+// ring the next time the clock shows their time, so for them only the day
+// whose wake up is within a day from now is returned, today or not.
+func alarmDays(p *Plan, now time.Time, options alarmOptions) []Day {
+	now = wallClock(now, p.StartDate.Location())
+	first, end := alarmWindow(now, options)
+	var days []Day
+	for _, d := range p.Days() {
+		if d.Date.Before(first) || !d.Date.Before(end) {
+			continue
+		}
+		if options.OneShot && (!d.Wake.After(now) || d.Wake.Sub(now) > 24*time.Hour) {
+			continue
+		}
+		days = append(days, d)
+	}
+	return days
+}
+
+// planAlarms returns the alarms for days of p, with their pre-alarms and
+// backup alarms, leaving out paused days.
+func planAlarms(p *Plan, days []Day, options alarmOptions) []Alarm {
+	var alarms []Alarm
+	for _, day := range days {
+		if p.InPause(day.Date) {
+			continue
+		}
+		alarm := Alarm{
+			Time:     day.Wake,
+			Label:    alarmLabel(options.Label, p, day),
+			Key:      "wake " + options.Label,
+			OneShot:  options.OneShot,
+			Vibrate:  options.Vibrate,
+			Ringtone: options.Ringtone,
+			SkipUI:   options.SkipUI,
+		}
+		if options.PreAlarm > 0 {
+			pre := alarm
+			pre.Time = alarm.Time.Add(-options.PreAlarm)
+			pre.Label = tr.Sprintf("%s (pre-alarm)", alarm.Label)
+			pre.Key = "pre-alarm " + options.Label
+			alarms = append(alarms, pre)
+		}
+		alarms = append(alarms, alarm)
+		if options.Backup > 0 {
+			backup := alarm
+			backup.Time = alarm.Time.Add(options.Backup)
+			backup.Label = tr.Sprintf("%s (backup)", alarm.Label)
+			backup.Key = "backup " + options.Label
+			alarms = append(alarms, backup)
+		}
+	}
+	return alarms
+}
+
+// planReminders returns the bedtime and wind-down reminders of p that are
+// due within a day from now, leaving out paused days. Timers count down at
+// most a day, so later reminders are left for a later sync.
+func planReminders(p *Plan, now time.Time, options alarmOptions) []Alarm {
+	now = wallClock(now, p.StartDate.Location())
+	offsets := slices.Sorted(slices.Values(options.WindDown))
+	slices.Reverse(offsets)
+	offsets = slices.Compact(offsets)
+	if options.BedtimeReminder {
+		offsets = append(offsets, 0)
+	}
+	var reminders []Alarm
+	for _, day := range p.Days() {
+		if p.InPause(day.Date) {
+			continue
+		}
+		for _, offset := range offsets {
+			at := day.Bedtime.Add(-offset)
+			if !at.After(now) || at.Sub(now) > 24*time.Hour {
+				continue
+			}
+			label, key := tr.Sprintf("Bedtime: %s", tr.clock(day.Bedtime)), "bedtime"
+			if offset > 0 {
+				label = tr.Sprintf("Bedtime in %s: %s", shortDuration(offset), tr.clock(day.Bedtime))
+				key = "wind-down " + offset.String()
+			}
+			reminders = append(reminders, Alarm{Time: at, Label: label, Key: key, SkipUI: options.SkipUI, Reminder: true})
+		}
+	}
+	return reminders
+}
+
+// keptAlarms returns the alarms of p for today in have that are not in want
+// but have yet to ring, as after midnight, when the window has moved on to
+// tomorrow. They stay set until their time has passed, since dismissing
+// them would skip the ring.
+func keptAlarms(p *Plan, have []CreatedAlarm, want []Alarm, options alarmOptions) []Alarm {
+	now := timeNow()
+	today := dateOf(wallClock(now, p.StartDate.Location()))
+	var days []Day
+	for _, d := range p.Days() {
+		if d.Date.Equal(today) {
+			days = append(days, d)
+		}
+	}
+	var kept []Alarm
+	for _, alarm := range planAlarms(p, days, options) {
+		if !alarm.Time.After(now) || slices.ContainsFunc(want, func(w Alarm) bool { return sameAlarm(w, alarm) }) {
+			continue
+		}
+		if slices.ContainsFunc(have, func(c CreatedAlarm) bool { return c.Plan == p.id() && sameAlarm(c.Alarm, alarm) }) {
+			kept = append(kept, alarm)
+		}
+	}
+	return kept
+}
+
+// syncAlarms brings the alarms of each of backends in line with p, and
+// remembers the alarms it set so they can be dismissed later. If there are
+// several backends, it sums up how it went on each. Syncing again without
+// changes to the plan or the date does nothing, so it is safe to run from
+// cron or a timer.
+func syncAlarms(w io.Writer, backends []alarmBackend, p *Plan, options alarmOptions) error {
+	created, err := loadCreatedAlarms()
+	if err != nil {
+		return err
+	}
+	days := alarmDays(p, timeNow(), options)
+	for _, day := range days {
+		if p.InPause(day.Date) {
+			tr.Fprintf(w, "Skipping alarm for %s: the plan is paused.\n", tr.day(day.Wake))
+		}
+	}
+	want := append(planAlarms(p, days, options), planReminders(p, timeNow(), options)...)
+	var failed []string
+	var results []alarmResult
+	for _, b := range backends {
+		target := b.Target()
+		var have []CreatedAlarm
+		for _, c := range created {
+			if c.Target == target {
+				have = append(have, c)
+			}
+		}
+		result := setAlarms(w, b, p.id(), append(slices.Clip(want), keptAlarms(p, have, want, options)...), have)
+		results = append(results, result)
+		if n, ok := b.(notifier); ok && options.Notify && result.Err == nil {
+			text := tr.Sprintf("%d alarms set, %d dismissed, %d failed", len(result.Created), len(result.Dismissed), result.Failed)
+			if err := n.Notify(tr.Sprintf("Sleep plan alarms"), text); err != nil {
+				tr.Fprintf(w, "Error posting notification: %v\n", err)
+			}
+		}
+		created = slices.DeleteFunc(created, func(c CreatedAlarm) bool {
+			return c.Target == target && slices.ContainsFunc(result.Dismissed, func(d CreatedAlarm) bool { return sameAlarm(c.Alarm, d.Alarm) })
+		})
+		for _, alarm := range result.Created {
+			created = append(created, CreatedAlarm{Target: target, Plan: p.id(), Alarm: alarm})
+		}
+		if result.Failed > 0 || result.Err != nil {
+			failed = append(failed, result.Backend)
+		}
+	}
+	if err := saveCreatedAlarms(created); err != nil {
+		return tr.Errorf("error recording created alarms: %w", err)
+	}
+	if len(results) > 1 {
+		tr.Fprintln(w, "Summary:")
+		for _, r := range results {
+			if r.Err != nil {
+				tr.Fprintf(w, "  %s: not reachable: %v\n", r.Backend, r.Err)
+			} else {
+				tr.Fprintf(w, "  %s: %d alarms set, %d dismissed, %d failed\n", r.Backend, len(r.Created), len(r.Dismissed), r.Failed)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return tr.Errorf("some alarms could not be set via %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// setAlarms makes b have the alarms in want for the plan with the given ID,
+// given the alarms that were set with it before. Those that are still
+// wanted are kept; the others, for earlier days, paused days or other plans,
+// are dismissed first, since dismissing goes by label and would take new
+// alarms with it.
+func setAlarms(w io.Writer, b alarmBackend, plan string, want []Alarm, have []CreatedAlarm) alarmResult {
+	result := alarmResult{Backend: b.Name()}
+	wanted := func(c CreatedAlarm) bool {
+		return c.Plan == plan && slices.ContainsFunc(want, func(alarm Alarm) bool { return sameAlarm(alarm, c.Alarm) })
+	}
+
+	tr.Fprintf(w, "Setting alarms via %s...\n", b.Name())
+
+	for _, c := range have {
+		if wanted(c) {
+			continue
+		}
+		// An alarm the backend cannot dismiss is left to the user and
+		// forgotten, so that it does not fail every sync.
+		if err := b.Delete(c.Alarm); errors.Is(err, errors.ErrUnsupported) {
+			tr.Fprintf(w, "Cancel %q on the device yourself: %v\n", c.Label, err)
+			result.Dismissed = append(result.Dismissed, c)
+			continue
+		} else if err != nil {
+			tr.Fprintf(w, "Error dismissing alarm %q: %v\n", c.Label, err)
+			if deviceUnavailable(err) {
+				result.Err = err
+				return result
+			}
+			result.Failed++
+			continue
+		}
+		tr.Fprintf(w, "Dismissed alarm %q.\n", c.Label)
+		result.Dismissed = append(result.Dismissed, c)
+	}
+
+	for _, alarm := range want {
+		wakeTime := alarm.Time
+		if slices.ContainsFunc(have, func(c CreatedAlarm) bool { return wanted(c) && sameAlarm(c.Alarm, alarm) }) {
+			if alarm.Reminder {
+				tr.Fprintf(w, "Reminder %q is already set.\n", alarm.Label)
+			} else {
+				tr.Fprintf(w, "Alarm for %s is already set.\n", tr.day(wakeTime))
+			}
+			continue
+		}
+		if alarm.Reminder {
+			tr.Fprintf(w, "Setting reminder %q for %s\n", alarm.Label, tr.clock(alarm.Time))
+		} else {
+			tr.Fprintf(w, "Setting alarm for %s: %s\n", tr.day(wakeTime), tr.clock(wakeTime))
+		}
+		if err := b.Create(alarm); err != nil {
+			if alarm.Reminder {
+				tr.Fprintf(w, "Error setting reminder %q: %v\n", alarm.Label, err)
+			} else {
+				tr.Fprintf(w, "Error setting alarm for %s: %v\n", tr.day(wakeTime), err)
+			}
+			if deviceUnavailable(err) {
+				result.Err = err
+				return result
+			}
+			result.Failed++
+			continue
+		}
+		if alarm.Reminder {
+			tr.Fprintf(w, "Reminder %q sent successfully.\n", alarm.Label)
+		} else {
+			tr.Fprintf(w, "Alarm for %s sent successfully.\n", tr.day(wakeTime))
+		}
+		result.Created = append(result.Created, alarm)
+	}
+	return result
+}
+
+// androidBackend sets alarms with the AlarmClock intents of Android, which
+// am starts from a shell on the device.
+type androidBackend struct {
+	name   string
+	target alarmTarget
+	// am is the command that starts activities.
+	am    string
+	shell func(command string) (string, error)
+}
+
+// newADBBackend returns a backend that runs am through the adb server on
+// the device with the given serial.
+func newADBBackend(client *adbClient, serial string) *androidBackend {
+	return &androidBackend{
+		name:   "ADB " + serial,
+		target: alarmTarget{Backend: "adb", Device: serial},
+		am:     "am",
+		shell: func(command string) (string, error) {
+			return client.Shell(serial, command)
+		},
+	}
+}
+
+// newADBBackends opens an ADB backend for each of the devices with the
+// given serials.
+func newADBBackends(a *app, devices []string) ([]alarmBackend, error) {
+	client := newADBClient(a.getenv)
+	available, err := client.Devices()
+	if err != nil {
+		return nil, err
+	}
+	chosen, err := chooseDevices(devices, available)
+	if err != nil {
+		return nil, err
+	}
+	var backends []alarmBackend
+	for _, d := range chosen {
+		backends = append(backends, newADBBackend(client, d.Serial))
+	}
+	return backends, nil
+}
+
+// shellQuote quotes s as a single word for the device's shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// startActivity starts the activity that handles the intent action, with
+// extras given as am arguments, and waits until it has launched so that the
+// clock app sees one intent at a time.
+func (b *androidBackend) startActivity(action string, extras ...string) error {
+	command := strings.Join(append([]string{b.am, "start", "-W", "-a", action, "-f", "0x10000000"}, extras...), " ")
+	output, err := b.shell(command)
+	if err != nil {
+		return err
+	}
+	// am reports a missing activity on its output and still succeeds.
+	if _, message, ok := strings.Cut(output, "Error: "); ok {
+		return tr.Errorf("am start: %s", strings.TrimSpace(message))
+	}
+	return nil
+}
+
+func (b *androidBackend) Name() string {
+	return b.name
+}
+
+func (b *androidBackend) Target() alarmTarget {
+	return b.target
+}
+
+// untilReminder returns how long from now the timer of a reminder rings.
+func untilReminder(reminder Alarm) time.Duration {
+	now := timeNow()
+	t := reminder.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()).Sub(now)
+}
+
+// Create sets an alarm that repeats on the weekday of alarm.Time or, if it
+// is one-shot, rings the next time the clock shows its time. Reminders
+// start a timer that rings at alarm.Time.
+func (b *androidBackend) Create(alarm Alarm) error {
+	if alarm.Reminder {
+		seconds := int(untilReminder(alarm).Round(time.Second).Seconds())
+		if seconds < 1 || seconds > 24*60*60 {
+			return tr.Errorf("a timer cannot ring at %s %s", tr.day(alarm.Time), tr.clock(alarm.Time))
+		}
+		extras := []string{
+			"--ei", "android.intent.extra.alarm.LENGTH", strconv.Itoa(seconds),
+			"--es", "android.intent.extra.alarm.MESSAGE", shellQuote(alarm.Label),
+		}
+		if alarm.SkipUI {
+			extras = append(extras, "--ez", "android.intent.extra.alarm.SKIP_UI", "true")
+		}
+		return b.startActivity("android.intent.action.SET_TIMER", extras...)
+	}
+	extras := []string{
+		"--ei", "android.intent.extra.alarm.HOUR", strconv.Itoa(alarm.Time.Hour()),
+		"--ei", "android.intent.extra.alarm.MINUTES", strconv.Itoa(alarm.Time.Minute()),
+	}
+	if !alarm.OneShot {
+		extras = append(extras, "--eia", "android.intent.extra.alarm.DAYS", strconv.Itoa(int(alarm.Time.Weekday())+1))
+	}
+	extras = append(extras,
+		"--es", "android.intent.extra.alarm.MESSAGE", shellQuote(alarm.Label),
+		"--ez", "android.intent.extra.alarm.VIBRATE", strconv.FormatBool(alarm.Vibrate),
+	)
+	if alarm.Ringtone != "" {
+		extras = append(extras, "--es", "android.intent.extra.alarm.RINGTONE", shellQuote(alarm.Ringtone))
+	}
+	if alarm.SkipUI {
+		extras = append(extras, "--ez", "android.intent.extra.alarm.SKIP_UI", "true")
+	}
+	return b.startActivity("android.intent.action.SET_ALARM", extras...)
+}
+
+// List reads the alarm clocks scheduled on the device from dumpsys. They
+// have no labels.
+func (b *androidBackend) List() ([]Alarm, error) {
+	output, err := b.shell("dumpsys alarm")
+	if err != nil {
+		return nil, err
+	}
+	return parseDumpsysAlarm(output)
+}
+
+// Delete dismisses the alarms labelled alarm.Label. The clock app deletes
+// a one-shot alarm that is dismissed, but only skips the next ring of a
+// weekly alarm, and only if it is within a day. A weekly alarm is dismissed
+// all the same, and left for the user to delete. A reminder is gone once
+// its timer has rung, and cannot be dismissed before, since the AlarmClock
+// intents cannot cancel a timer.
+func (b *androidBackend) Delete(alarm Alarm) error {
+	if alarm.Reminder {
+		if untilReminder(alarm) > 0 {
+			return tr.Errorf("timers cannot be cancelled through the clock app: %w", errors.ErrUnsupported)
+		}
+		return nil
+	}
+	if err := b.startActivity("android.intent.action.DISMISS_ALARM",
+		"--es", "android.intent.extra.alarm.SEARCH_MODE", "android.label",
+		"--es", "android.intent.extra.alarm.MESSAGE", shellQuote(alarm.Label),
+	); err != nil {
+		return err
+	}
+	if !alarm.OneShot {
+		return tr.Errorf("the clock app can only skip the next ring of a weekly alarm: %w", errors.ErrUnsupported)
+	}
+	return nil
+}
@@ -0,0 +1,626 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBackend keeps alarms in memory, as if on device. Creating an alarm
+// whose label is in fail returns that error.
+type fakeBackend struct {
+	device string
+	alarms []Alarm
+	fail   map[string]error
+}
+
+func (f *fakeBackend) Name() string {
+	return f.Target().String()
+}
+
+func (f *fakeBackend) Target() alarmTarget {
+	return alarmTarget{Backend: "fake", Device: f.device}
+}
+
+func (f *fakeBackend) Create(alarm Alarm) error {
+	if err := f.fail[alarm.Label]; err != nil {
+		return err
+	}
+	f.alarms = append(f.alarms, alarm)
+	return nil
+}
+
+func (f *fakeBackend) List() ([]Alarm, error) {
+	return slices.Clone(f.alarms), nil
+}
+
+func (f *fakeBackend) Delete(alarm Alarm) error {
+	f.alarms = slices.DeleteFunc(f.alarms, func(a Alarm) bool { return a.Label == alarm.Label })
+	return nil
+}
+
+// useFakeBackend makes a fake backend available as the "fake" alarm
+// backend for the rest of the test.
+func useFakeBackend(t *testing.T) *fakeBackend {
+	t.Helper()
+	fake := &fakeBackend{}
+	alarmBackends["fake"] = func(a *app, devices []string) ([]alarmBackend, error) { return []alarmBackend{fake}, nil }
+	t.Cleanup(func() { delete(alarmBackends, "fake") })
+	return fake
+}
+
+func alarmLabels(alarms []Alarm) []string {
+	var labels []string
+	for _, alarm := range alarms {
+		labels = append(labels, alarm.Label)
+	}
+	return labels
+}
+
+func TestAlarmDays(t *testing.T) {
+	p := testPlan(t)
+	p.Schedule = append(p.Schedule, p.Schedule...)
+	p.Schedule = append(p.Schedule, p.Schedule...)
+	dates := func(days []Day) []string {
+		var dates []string
+		for _, d := range days {
+			dates = append(dates, d.Date.Format("01-02"))
+		}
+		return dates
+	}
+	tests := []struct {
+		now     string
+		options alarmOptions
+		want    []string
+	}{
+		{"2025-07-12", alarmOptions{Days: 7}, []string{"07-13", "07-14", "07-15", "07-16", "07-17", "07-18", "07-19"}},
+		{"2025-07-13", alarmOptions{Days: 3}, []string{"07-14", "07-15", "07-16"}},
+		{"2025-07-13", alarmOptions{Days: 3, NoSkipToday: true}, []string{"07-13", "07-14", "07-15"}},
+		{"2025-07-22", alarmOptions{Days: 7}, []string{"07-23", "07-24"}},
+		{"2025-07-24", alarmOptions{Days: 7}, nil},
+	}
+	for _, test := range tests {
+		now := testDate(test.now).Add(20 * time.Hour)
+		if got := dates(alarmDays(p, now, test.options)); !slices.Equal(got, test.want) {
+			t.Errorf("%s %+v: expected %q, got %q", test.now, test.options, test.want, got)
+		}
+	}
+}
+
+func TestPlanAlarms(t *testing.T) {
+	p := testPlan(t)
+	options := alarmOptions{Label: "Day {number}/{days}: {time} ({day}, target {target})", PreAlarm: 10 * time.Minute, Backup: 5 * time.Minute, Vibrate: true}
+	alarms := planAlarms(p, p.Days()[1:2], options)
+	want := []string{
+		"Day 2/3: 09:00 (Mon, Jul 14, target 08:00) (pre-alarm)",
+		"Day 2/3: 09:00 (Mon, Jul 14, target 08:00)",
+		"Day 2/3: 09:00 (Mon, Jul 14, target 08:00) (backup)",
+	}
+	if got := alarmLabels(alarms); !slices.Equal(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	times := []string{alarms[0].Time.Format("15:04"), alarms[1].Time.Format("15:04"), alarms[2].Time.Format("15:04")}
+	if !slices.Equal(times, []string{"08:50", "09:00", "09:05"}) || !alarms[0].Vibrate {
+		t.Errorf("Unexpected alarms %+v", alarms)
+	}
+
+	for _, label := range []string{"", "Up {day}", "Day {number}", "{day} {weekday}", "Wake up"} {
+		err := checkAlarmLabel(label)
+		if ok := label != "{day} {weekday}" && label != "Wake up"; ok != (err == nil) {
+			t.Errorf("%q: unexpected error %v", label, err)
+		}
+	}
+}
+
+func TestOneShotAlarmDays(t *testing.T) {
+	p := testPlan(t)
+	at := func(date, clock string) time.Time { return atClock(testDate(date), testClock(clock)) }
+	options := alarmOptions{Days: 7, OneShot: true}
+	if days := alarmDays(p, at("2025-07-13", "22:00"), options); len(days) != 1 || days[0].Number != 2 {
+		t.Errorf("Expected only tomorrow's one-shot alarm, got %+v", days)
+	}
+	if days := alarmDays(p, at("2025-07-12", "08:00"), options); len(days) != 0 {
+		t.Errorf("Expected no one-shot alarm more than a day ahead, got %+v", days)
+	}
+	// A sync after midnight sets the wake up of the same day.
+	if days := alarmDays(p, at("2025-07-14", "00:30"), options); len(days) != 1 || days[0].Number != 2 {
+		t.Errorf("Expected today's one-shot alarm after midnight, got %+v", days)
+	}
+	if days := alarmDays(p, at("2025-07-14", "09:30"), options); len(days) != 1 || days[0].Number != 3 {
+		t.Errorf("Expected tomorrow's one-shot alarm once today's has rung, got %+v", days)
+	}
+}
+
+func TestPlanReminders(t *testing.T) {
+	p := testPlan(t)
+	bedtime := p.Days()[1].Bedtime
+	options := alarmOptions{BedtimeReminder: true, WindDown: []time.Duration{30 * time.Minute, time.Hour, 30 * time.Minute}}
+	reminders := planReminders(p, bedtime.Add(-150*time.Minute), options)
+	want := []string{
+		"Bedtime in 1h00m: " + tr.clock(bedtime),
+		"Bedtime in 30m: " + tr.clock(bedtime),
+		"Bedtime: " + tr.clock(bedtime),
+	}
+	if got := alarmLabels(reminders); !slices.Equal(got, want) {
+		t.Fatalf("Expected %q, got %q", want, got)
+	}
+	if !reminders[0].Reminder || !reminders[0].Time.Equal(bedtime.Add(-time.Hour)) {
+		t.Errorf("Unexpected reminder %+v", reminders[0])
+	}
+	if reminders := planReminders(p, bedtime.Add(-150*time.Minute), alarmOptions{}); len(reminders) != 0 {
+		t.Errorf("Expected no reminders unless asked for, got %+v", reminders)
+	}
+
+	for _, s := range []string{"", "30m", "1h,30m"} {
+		if err := checkWindDown(s); err != nil {
+			t.Errorf("%q: unexpected error %v", s, err)
+		}
+	}
+	for _, s := range []string{"soon", "0s", "4h", "1h,-5m"} {
+		if err := checkWindDown(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestSetAlarms(t *testing.T) {
+	p := testPlan(t)
+	fake := &fakeBackend{fail: map[string]error{"Sleep Adjustment Wake Up: Tue, Jul 15": errors.New("no device")}}
+	var out bytes.Buffer
+	result := setAlarms(&out, fake, p.id(), planAlarms(p, p.Days(), alarmOptions{}), nil)
+	if len(result.Created) != 2 || result.Failed != 1 || result.Err != nil {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if got := alarmLabels(fake.alarms); !slices.Equal(got, []string{"Sleep Adjustment Wake Up: Sun, Jul 13", "Sleep Adjustment Wake Up: Mon, Jul 14"}) {
+		t.Errorf("Unexpected alarms %q", got)
+	}
+	if !fake.alarms[1].Time.Equal(atClock(testDate("2025-07-14"), testClock("09:00"))) {
+		t.Errorf("Expected the second alarm at 09:00 on Jul 14, got %s", fake.alarms[1].Time)
+	}
+	if !strings.Contains(out.String(), "Setting alarms via fake...") || !strings.Contains(out.String(), "Error setting alarm for Tue, Jul 15: no device") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+
+	// The alarm for Sunday is kept and Tuesday's is retried; an alarm of
+	// another plan and one that moved are dismissed before setting the new.
+	stale := Alarm{Time: atClock(testDate("2025-07-14"), testClock("06:00")), Label: "Sleep Adjustment Wake Up: Mon, Jul 14"}
+	have := []CreatedAlarm{
+		{Plan: p.id(), Alarm: fake.alarms[0]},
+		{Plan: p.id(), Alarm: stale},
+		{Plan: "old", Alarm: Alarm{Time: fake.alarms[0].Time, Label: "old"}},
+	}
+	fake = &fakeBackend{alarms: []Alarm{have[0].Alarm, stale, have[2].Alarm}}
+	out.Reset()
+	result = setAlarms(&out, fake, p.id(), planAlarms(p, p.Days(), alarmOptions{}), have)
+	if len(result.Dismissed) != 2 || len(result.Created) != 2 || result.Failed != 0 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if got := alarmLabels(fake.alarms); !slices.Equal(got, []string{"Sleep Adjustment Wake Up: Sun, Jul 13", "Sleep Adjustment Wake Up: Mon, Jul 14", "Sleep Adjustment Wake Up: Tue, Jul 15"}) {
+		t.Errorf("Unexpected alarms %q", got)
+	}
+	if !strings.Contains(out.String(), "Alarm for Sun, Jul 13 is already set.") {
+		t.Errorf("Expected the kept alarm to be reported:\n%s", out.String())
+	}
+}
+
+func TestSyncAlarms(t *testing.T) {
+	setConfigDir(t.TempDir())
+	p := testPlan(t)
+	p.Schedule = append(p.Schedule, p.Schedule...)
+	p.Schedule = append(p.Schedule, p.Schedule...)
+	timeNow = func() time.Time { return testDate("2025-07-12").Add(20 * time.Hour) }
+	defer func() { timeNow = time.Now }()
+
+	fake := &fakeBackend{}
+	var out bytes.Buffer
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, alarmOptions{Days: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.alarms) != 7 || strings.Contains(out.String(), "Summary") {
+		t.Errorf("Expected a week of alarms and no summary for one backend, got %d:\n%s", len(fake.alarms), out.String())
+	}
+
+	out.Reset()
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, alarmOptions{Days: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.alarms) != 7 || strings.Contains(out.String(), "sent successfully") {
+		t.Errorf("Expected syncing again to change nothing, got %d alarms:\n%s", len(fake.alarms), out.String())
+	}
+
+	timeNow = func() time.Time { return testDate("2025-07-14").Add(20 * time.Hour) }
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, alarmOptions{Days: 7}); err != nil {
+		t.Fatal(err)
+	}
+	created, _ := loadCreatedAlarms()
+	if len(fake.alarms) != 7 || len(created) != 7 || fake.alarms[0].Label != "Sleep Adjustment Wake Up: Tue, Jul 15" || fake.alarms[6].Label != "Sleep Adjustment Wake Up: Mon, Jul 21" {
+		t.Errorf("Expected the window to move forward by two days, got %q and %d records", alarmLabels(fake.alarms), len(created))
+	}
+
+	ok := &fakeBackend{}
+	gone := &fakeBackend{device: "gone", fail: map[string]error{"Sleep Adjustment Wake Up: Wed, Jul 16": errUnauthorized}}
+	out.Reset()
+	err := syncAlarms(&out, []alarmBackend{ok, gone}, p, alarmOptions{Days: 2})
+	if err == nil {
+		t.Error("Expected an error when a device could not be reached")
+	}
+	if !strings.Contains(out.String(), "Summary:\n  fake: 0 alarms set, 5 dismissed, 0 failed\n  fake gone: not reachable: device unauthorized") {
+		t.Errorf("Unexpected summary:\n%s", out.String())
+	}
+}
+
+func TestSyncAlarmsAfterLocaleChange(t *testing.T) {
+	setConfigDir(t.TempDir())
+	p := testPlan(t)
+	timeNow = func() time.Time { return testDate("2025-07-12").Add(20 * time.Hour) }
+	defer func() { timeNow = time.Now }()
+	options := alarmOptions{Days: 7, PreAlarm: 10 * time.Minute}
+
+	fake := &fakeBackend{}
+	var out bytes.Buffer
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil {
+		t.Fatal(err)
+	}
+	useLocale(t, newLocale("de_DE"))
+	out.Reset()
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.alarms) != 6 || fake.alarms[0].Label != "Sleep Adjustment Wake Up: Sun, Jul 13 (pre-alarm)" {
+		t.Errorf("Expected another language to keep the alarms, got %q:\n%s", alarmLabels(fake.alarms), out.String())
+	}
+
+	options.Label = "Aufstehen: {day}"
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.alarms) != 6 || fake.alarms[1].Label != "Aufstehen: So, 13. Jul" {
+		t.Errorf("Expected another label template to replace the alarms, got %q", alarmLabels(fake.alarms))
+	}
+}
+
+func TestSyncAlarmsAfterOptionChange(t *testing.T) {
+	setConfigDir(t.TempDir())
+	p := testPlan(t)
+	timeNow = func() time.Time { return testDate("2025-07-12").Add(20 * time.Hour) }
+	defer func() { timeNow = time.Now }()
+	options := alarmOptions{Days: 7}
+
+	fake := &fakeBackend{}
+	var out bytes.Buffer
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range []func(){
+		func() { options.Vibrate = true },
+		func() { options.Ringtone = "content://media/internal/audio/media/42" },
+		func() { options.OneShot = true },
+	} {
+		change()
+		out.Reset()
+		if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(out.String(), "already set") || len(fake.alarms) == 0 {
+			t.Fatalf("Expected changed options to replace the alarms, got %+v:\n%s", fake.alarms, out.String())
+		}
+		for _, alarm := range fake.alarms {
+			if alarm.Vibrate != options.Vibrate || alarm.Ringtone != options.Ringtone || alarm.OneShot != options.OneShot {
+				t.Errorf("Expected alarms with options %+v, got %+v", options, alarm)
+			}
+		}
+	}
+	out.Reset()
+	if err := syncAlarms(&out, []alarmBackend{fake}, p, options); err != nil || !strings.Contains(out.String(), "already set") {
+		t.Errorf("Expected unchanged options to keep the alarms, got %v:\n%s", err, out.String())
+	}
+}
+
+func TestVerifyAlarms(t *testing.T) {
+	at := func(date, clock string) time.Time { return atClock(testDate(date), testClock(clock)) }
+	first, end := testDate("2025-07-13"), testDate("2025-07-16")
+	stale := Alarm{Time: at("2025-07-14", "06:00"), Label: "stale"}
+	fake := &fakeBackend{alarms: []Alarm{
+		{Time: at("2025-07-13", "07:00"), Label: "a"},
+		{Time: at("2025-07-15", "07:30"), Label: "c"},
+		stale,
+		{Time: at("2025-07-14", "06:30"), Label: "mine"},
+		{Time: at("2025-07-20", "07:00"), Label: "stale"},
+	}}
+	want := []Alarm{{Time: at("2025-07-13", "07:00"), Label: "a"}, {Time: at("2025-07-14", "07:00"), Label: "b"}, {Time: at("2025-07-15", "07:00"), Label: "c"}}
+	// Only the alarm eepy recorded on a verified day is extra; the user's
+	// own alarm and the one after the verified dates are not.
+	created := []CreatedAlarm{{Alarm: stale}, {Alarm: Alarm{Time: at("2025-07-20", "07:00"), Label: "stale"}}}
+	report, err := verifyAlarms(fake, want, ownedAlarm(created, first, end, want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OK() || !slices.Equal(alarmLabels(report.Missing), []string{"b"}) || !slices.Equal(alarmLabels(report.Extra), []string{"stale"}) || !report.Extra[0].Time.Equal(stale.Time) {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if len(report.Mismatched) != 1 || report.Mismatched[0].Want.Label != "c" || !report.Mismatched[0].Have.Time.Equal(at("2025-07-15", "07:30")) {
+		t.Errorf("Expected the alarm for Jul 15 to be mismatched, got %+v", report.Mismatched)
+	}
+
+	// Alarms read without labels match by time.
+	fake = &fakeBackend{alarms: []Alarm{{Time: at("2025-07-13", "07:00")}, {Time: at("2025-07-14", "07:00")}, {Time: at("2025-07-15", "07:00")}}}
+	if report, err := verifyAlarms(fake, want, ownedAlarm(nil, first, end, want)); err != nil || !report.OK() {
+		t.Errorf("Expected unlabelled alarms to match, got %+v, %v", report, err)
+	}
+	fake.alarms = append(fake.alarms, Alarm{Time: at("2025-07-14", "06:00")})
+	if report, err := verifyAlarms(fake, want, ownedAlarm(created, first, end, want)); err != nil || len(report.Extra) != 1 {
+		t.Errorf("Expected an unlabelled alarm eepy recorded to be extra, got %+v, %v", report, err)
+	}
+
+	var out bytes.Buffer
+	writeAlarmReport(&out, AlarmReport{
+		Backend:    "fake",
+		Missing:    want[1:2],
+		Mismatched: []AlarmMismatch{{Want: want[2], Have: Alarm{Time: at("2025-07-15", "07:30")}}},
+		Extra:      []Alarm{{Time: at("2025-07-20", "07:00")}},
+	})
+	if want := "fake:\n  Missing:    Mon, Jul 14 07:00\n  Mismatched: Tue, Jul 15 07:00 is set for 07:30\n  Extra:      Sun, Jul 20 07:00\n"; out.String() != want {
+		t.Errorf("Unexpected report:\n%s\nexpected:\n%s", out.String(), want)
+	}
+}
+
+func TestAndroidBackend(t *testing.T) {
+	var commands []string
+	output := "Starting: Intent { act=android.intent.action.SET_ALARM }"
+	b := &androidBackend{name: "test", am: "am", shell: func(command string) (string, error) {
+		commands = append(commands, command)
+		return output, nil
+	}}
+
+	alarm := Alarm{Time: atClock(testDate("2025-07-13"), testClock("06:30")), Label: "Wake up, it's Sunday", Vibrate: true}
+	if err := b.Create(alarm); err != nil {
+		t.Fatal(err)
+	}
+	want := `am start -W -a android.intent.action.SET_ALARM -f 0x10000000 --ei android.intent.extra.alarm.HOUR 6 --ei android.intent.extra.alarm.MINUTES 30 --eia android.intent.extra.alarm.DAYS 1 --es android.intent.extra.alarm.MESSAGE 'Wake up, it'\''s Sunday' --ez android.intent.extra.alarm.VIBRATE true`
+	if commands[0] != want {
+		t.Errorf("Unexpected command:\n%s\nexpected:\n%s", commands[0], want)
+	}
+
+	special := Alarm{Time: alarm.Time, Label: "x", OneShot: true, Ringtone: "content://media/internal/audio/media/42", SkipUI: true}
+	if err := b.Create(special); err != nil {
+		t.Fatal(err)
+	}
+	want = `am start -W -a android.intent.action.SET_ALARM -f 0x10000000 --ei android.intent.extra.alarm.HOUR 6 --ei android.intent.extra.alarm.MINUTES 30 --es android.intent.extra.alarm.MESSAGE 'x' --ez android.intent.extra.alarm.VIBRATE false --es android.intent.extra.alarm.RINGTONE 'content://media/internal/audio/media/42' --ez android.intent.extra.alarm.SKIP_UI true`
+	if commands[1] != want {
+		t.Errorf("Unexpected command:\n%s\nexpected:\n%s", commands[1], want)
+	}
+	if err := b.Delete(special); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(commands[2], "DISMISS_ALARM") || !strings.Contains(commands[2], "SEARCH_MODE android.label") {
+		t.Errorf("Expected a dismiss by label, got %s", commands[2])
+	}
+	if err := b.Delete(alarm); !errors.Is(err, errors.ErrUnsupported) || !strings.Contains(commands[3], "DISMISS_ALARM") {
+		t.Errorf("Expected a weekly alarm to be dismissed and left to the user, got %v", err)
+	}
+	commands = commands[:2]
+
+	now := atClock(testDate("2025-07-13"), testClock("20:30"))
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	reminder := Alarm{Time: now.Add(90 * time.Minute), Label: "Bedtime: 22:00", Reminder: true}
+	if err := b.Create(reminder); err != nil {
+		t.Fatal(err)
+	}
+	want = `am start -W -a android.intent.action.SET_TIMER -f 0x10000000 --ei android.intent.extra.alarm.LENGTH 5400 --es android.intent.extra.alarm.MESSAGE 'Bedtime: 22:00'`
+	if commands[2] != want {
+		t.Errorf("Unexpected command:\n%s\nexpected:\n%s", commands[2], want)
+	}
+	if err := b.Create(Alarm{Time: now.Add(25 * time.Hour), Label: "late", Reminder: true}); err == nil {
+		t.Errorf("Expected a timer more than a day ahead to be refused")
+	}
+
+	// A running timer cannot be cancelled, so setting alarms for another
+	// plan leaves it to the user and forgets it.
+	var out bytes.Buffer
+	result := setAlarms(&out, b, "new", nil, []CreatedAlarm{{Plan: "old", Alarm: reminder}})
+	if len(result.Dismissed) != 1 || result.Failed != 0 || !strings.Contains(out.String(), `Cancel "Bedtime: 22:00" on the device yourself`) {
+		t.Errorf("Unexpected result %+v:\n%s", result, out.String())
+	}
+	now = now.Add(2 * time.Hour)
+	if err := b.Delete(reminder); err != nil || len(commands) != 3 {
+		t.Errorf("Expected a timer that has rung to need no dismissing, got %v", err)
+	}
+
+	output = "Starting: Intent { ... }\nError: Activity not started, unable to resolve Intent"
+	if err := b.Create(alarm); err == nil || !strings.Contains(err.Error(), "unable to resolve Intent") {
+		t.Errorf("Expected am errors to be reported, got %v", err)
+	}
+}
+
+// clockApp plays the clock app behind the AlarmClock intents that an
+// androidBackend starts. Dismissing a one-shot alarm deletes it, while a
+// weekly alarm only skips its next ring, if that is within a day.
+type clockApp struct {
+	alarms []clockAlarm
+}
+
+type clockAlarm struct {
+	hour, minute int
+	// day is the weekday the alarm repeats on, counted from Sunday as 1,
+	// or 0 if it rings once.
+	day     int
+	label   string
+	skipped time.Time
+}
+
+// next returns when the alarm next rings after now, skipped or not.
+func (a clockAlarm) next(now time.Time) time.Time {
+	t := time.Date(now.Year(), now.Month(), now.Day(), a.hour, a.minute, 0, 0, now.Location())
+	for !t.After(now) || (a.day != 0 && int(t.Weekday())+1 != a.day) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// shellWords splits a command into words as the device's shell would,
+// understanding only the quoting of shellQuote.
+func shellWords(command string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	for _, r := range command {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && !quoted:
+			escaped, inWord = true, true
+		case r == '\'':
+			quoted, inWord = !quoted, true
+		case r == ' ' && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+func (c *clockApp) shell(command string) (string, error) {
+	words := shellWords(command)
+	// am start -W -a ACTION -f FLAGS, then extras as type, key, value.
+	extras := map[string]string{}
+	for i := 7; i+2 < len(words); i += 3 {
+		extras[strings.TrimPrefix(words[i+1], "android.intent.extra.alarm.")] = words[i+2]
+	}
+	switch words[4] {
+	case "android.intent.action.SET_ALARM":
+		hour, _ := strconv.Atoi(extras["HOUR"])
+		minute, _ := strconv.Atoi(extras["MINUTES"])
+		day, _ := strconv.Atoi(extras["DAYS"])
+		c.alarms = append(c.alarms, clockAlarm{hour: hour, minute: minute, day: day, label: extras["MESSAGE"]})
+	case "android.intent.action.DISMISS_ALARM":
+		now := timeNow()
+		c.alarms = slices.DeleteFunc(c.alarms, func(a clockAlarm) bool { return a.label == extras["MESSAGE"] && a.day == 0 })
+		for i, a := range c.alarms {
+			if next := a.next(now); a.label == extras["MESSAGE"] && next.Sub(now) <= 24*time.Hour {
+				c.alarms[i].skipped = next
+			}
+		}
+	}
+	return "Starting: Intent { ... }", nil
+}
+
+func (c *clockApp) labels() []string {
+	var labels []string
+	for _, a := range c.alarms {
+		labels = append(labels, a.label)
+	}
+	return labels
+}
+
+func TestClockAppRollingWindow(t *testing.T) {
+	p := testPlan(t)
+	now := testDate("2025-07-12").Add(20 * time.Hour)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	for _, oneShot := range []bool{false, true} {
+		setConfigDir(t.TempDir())
+		clock := &clockApp{}
+		b := &androidBackend{name: "test", target: alarmTarget{Backend: "adb", Device: "test"}, am: "am", shell: clock.shell}
+		options := alarmOptions{Days: 1, OneShot: oneShot}
+		var have []CreatedAlarm
+		var out bytes.Buffer
+		sync := func(at time.Time) {
+			now = at
+			if err := syncAlarms(&out, []alarmBackend{b}, p, options); err != nil {
+				t.Fatal(err)
+			}
+			var err error
+			if have, err = loadCreatedAlarms(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sync(testDate("2025-07-12").Add(20 * time.Hour))
+		// After midnight the window has moved on to Monday, but Sunday's
+		// alarm has yet to ring and must not be dismissed.
+		sync(testDate("2025-07-13").Add(time.Hour))
+		if got := clock.labels(); len(got) == 0 || got[0] != "Sleep Adjustment Wake Up: Sun, Jul 13" || !clock.alarms[0].skipped.IsZero() {
+			t.Errorf("one-shot %t: expected Sunday's alarm to still ring after midnight, got %+v:\n%s", oneShot, clock.alarms, out.String())
+		}
+		if len(have) == 0 || have[0].Label != "Sleep Adjustment Wake Up: Sun, Jul 13" {
+			t.Errorf("one-shot %t: expected Sunday's alarm to be remembered after midnight, got %+v", oneShot, have)
+		}
+		sync(testDate("2025-07-13").Add(20 * time.Hour))
+		if len(have) != 1 || have[0].Label != "Sleep Adjustment Wake Up: Mon, Jul 14" {
+			t.Errorf("one-shot %t: expected only Monday's alarm to be remembered, got %+v", oneShot, have)
+		}
+		if oneShot {
+			if got := clock.labels(); !slices.Equal(got, []string{"Sleep Adjustment Wake Up: Mon, Jul 14"}) {
+				t.Errorf("Expected Sunday's one-shot alarm to be deleted, got %q:\n%s", got, out.String())
+			}
+			continue
+		}
+		// Sunday's alarm next rings in a week, too far ahead to be skipped,
+		// so it stays set and the user is told to delete it.
+		if got := clock.labels(); !slices.Equal(got, []string{"Sleep Adjustment Wake Up: Sun, Jul 13", "Sleep Adjustment Wake Up: Mon, Jul 14"}) || !clock.alarms[0].skipped.IsZero() {
+			t.Errorf("Expected Sunday's weekly alarm to stay set, got %+v", clock.alarms)
+		}
+		if !strings.Contains(out.String(), `Cancel "Sleep Adjustment Wake Up: Sun, Jul 13" on the device yourself`) {
+			t.Errorf("Expected the user to be told to delete Sunday's alarm:\n%s", out.String())
+		}
+
+		// Dismissing Monday's alarm before it rings only skips that ring.
+		setAlarms(&out, b, "other", nil, have)
+		if len(clock.alarms) != 2 || !clock.alarms[1].skipped.Equal(atClock(testDate("2025-07-14"), testClock("09:00"))) {
+			t.Errorf("Expected Monday's weekly alarm to stay set with its next ring skipped, got %+v", clock.alarms)
+		}
+	}
+}
+
+func TestAlarmBackendSetting(t *testing.T) {
+	setConfigDir(t.TempDir())
+	timeNow = func() time.Time { return time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	fake := useFakeBackend(t)
+	runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-01-01")
+
+	if code, _, stderr := runTest(t, "", "alarms", "sync", "--alarm-backend", "pager"); code != 1 || !strings.Contains(stderr, `unknown alarm backend "pager"`) {
+		t.Errorf("Expected an unknown backend to be refused, got %d: %s", code, stderr)
+	}
+	if code, _, stderr := runTest(t, "", "config", "set", "alarm-backend", "fake"); code != 0 {
+		t.Fatalf("Expected the fake backend to be a valid setting: %s", stderr)
+	}
+	code, stdout, _ := runTest(t, "", "alarms", "sync")
+	if code != 0 || len(fake.alarms) != 3 || !strings.Contains(stdout, "via fake") {
+		t.Errorf("Expected the configured backend to set 3 alarms, got %d, %d alarms:\n%s", code, len(fake.alarms), stdout)
+	}
+
+	if code, _, stderr := runTest(t, "", "config", "set", "alarm-label", "Wake up"); code != 1 || !strings.Contains(stderr, "{day} or {number}") {
+		t.Errorf("Expected a label without a day to be refused, got %d: %s", code, stderr)
+	}
+	runTest(t, "", "config", "set", "alarm-label", "Day {number}/{days}")
+	if code, stdout, _ := runTest(t, "", "alarms", "sync"); code != 0 || !slices.Equal(alarmLabels(fake.alarms), []string{"Day 1/3", "Day 2/3", "Day 3/3"}) {
+		t.Errorf("Expected the alarms to be set again with the configured label, got %d, %q:\n%s", code, alarmLabels(fake.alarms), stdout)
+	}
+	code, stdout, _ = runTest(t, "", "alarms", "sync", "--bedtime-reminder", "--wind-down", "30m")
+	if code != 0 || len(fake.alarms) != 5 || !fake.alarms[3].Reminder || !strings.Contains(stdout, "Setting reminder") {
+		t.Errorf("Expected the first night's bedtime reminders to be set too, got %d, %q:\n%s", code, alarmLabels(fake.alarms), stdout)
+	}
+}
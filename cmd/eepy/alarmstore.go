@@ -0,0 +1,170 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/pflag"
+)
+
+// alarmTarget is where a backend sets alarms: the name of the backend, as
+// the alarm-backend setting has it, and the device, for backends that reach
+// several.
+type alarmTarget struct {
+	Backend string `json:"backend"`
+	Device  string `json:"device,omitempty"`
+}
+
+func (t alarmTarget) String() string {
+	if t.Device == "" {
+		return t.Backend
+	}
+	return t.Backend + " " + t.Device
+}
+
+// CreatedAlarm is an alarm eepy has set, remembered so that it can be
+// dismissed once its plan no longer needs it.
+type CreatedAlarm struct {
+	Target alarmTarget `json:"target"`
+	Plan   string      `json:"plan"`
+	Alarm
+}
+
+func loadCreatedAlarms() ([]CreatedAlarm, error) {
+	data, err := os.ReadFile(alarmsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var created []CreatedAlarm
+	if err := json.Unmarshal(data, &created); err != nil {
+		return nil, tr.Errorf("error reading created alarms: %w", err)
+	}
+	return created, nil
+}
+
+func saveCreatedAlarms(created []CreatedAlarm) error {
+	data, err := json.MarshalIndent(created, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(alarmsPath, data, 0644)
+}
+
+// openTarget opens the backend that set alarms on target.
+func (a *app) openTarget(target alarmTarget) (alarmBackend, error) {
+	if err := checkAlarmBackend(target.Backend); err != nil {
+		return nil, err
+	}
+	var devices []string
+	if target.Device != "" {
+		devices = []string{target.Device}
+	}
+	backends, err := alarmBackends[target.Backend](a, devices)
+	if err != nil {
+		return nil, err
+	}
+	return backends[0], nil
+}
+
+// dismissAlarms dismisses the created alarms that match and forgets them.
+// Alarms that cannot be dismissed are kept, so that a later attempt can try
+// again, unless the backend cannot dismiss them at all.
+func (a *app) dismissAlarms(w io.Writer, match func(CreatedAlarm) bool) error {
+	created, err := loadCreatedAlarms()
+	if err != nil {
+		return err
+	}
+	var targets []alarmTarget
+	for _, c := range created {
+		if match(c) && !slices.Contains(targets, c.Target) {
+			targets = append(targets, c.Target)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	failed := 0
+	for _, target := range targets {
+		onTarget := func(c CreatedAlarm) bool { return c.Target == target && match(c) }
+		b, err := a.openTarget(target)
+		if err != nil {
+			tr.Fprintf(w, "Could not reach %s to dismiss its alarms: %v\n", target, err)
+			for _, c := range created {
+				if onTarget(c) {
+					failed++
+				}
+			}
+			continue
+		}
+		dismissed := 0
+		created = slices.DeleteFunc(created, func(c CreatedAlarm) bool {
+			if !onTarget(c) {
+				return false
+			}
+			if err := b.Delete(c.Alarm); errors.Is(err, errors.ErrUnsupported) {
+				tr.Fprintf(w, "Cancel %q on the device yourself: %v\n", c.Label, err)
+				return true
+			} else if err != nil {
+				tr.Fprintf(w, "Error dismissing alarm %q: %v\n", c.Label, err)
+				failed++
+				return false
+			}
+			dismissed++
+			return true
+		})
+		if dismissed > 0 {
+			tr.Fprintf(w, "Dismissed %d alarms via %s.\n", dismissed, b.Name())
+		}
+	}
+	if err := saveCreatedAlarms(created); err != nil {
+		return tr.Errorf("error saving created alarms: %w", err)
+	}
+	if failed > 0 {
+		return tr.Errorf("%d alarms could not be dismissed; run 'eepy alarms clear' to try again", failed)
+	}
+	return nil
+}
+
+// dismissStaleAlarms dismisses the created alarms that match after a change
+// to the plan. Failing to do so does not undo the change, so it only warns.
+func (a *app) dismissStaleAlarms(match func(CreatedAlarm) bool) {
+	if err := a.dismissAlarms(a.stdout, match); err != nil {
+		tr.Fprintf(a.stderr, "Warning: %v\n", err)
+	}
+}
+
+func alarmsClearCommand(flags *pflag.FlagSet) runFunc {
+	devices := flags.StringSlice("device", nil, "Only clear alarms on these devices (serial or alias)")
+	return func(a *app, args []string) error {
+		serials, err := a.deviceSerials(*devices)
+		if err != nil {
+			return err
+		}
+		created, err := loadCreatedAlarms()
+		if err != nil {
+			return err
+		}
+		match := func(c CreatedAlarm) bool { return len(serials) == 0 || slices.Contains(serials, c.Target.Device) }
+		if !slices.ContainsFunc(created, match) {
+			tr.Fprintln(a.stdout, "No alarms to clear.")
+			return nil
+		}
+		return a.dismissAlarms(a.stdout, match)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDismissAlarms(t *testing.T) {
+	setConfigDir(t.TempDir())
+	timeNow = func() time.Time { return time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	fake := useFakeBackend(t)
+	runTest(t, "", "config", "set", "alarm-backend", "fake")
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:30", "--adjustment", "30m", "--start-date", "2025-01-01")
+	runTest(t, "", "alarms", "sync")
+	if got := alarmLabels(fake.alarms); len(got) != 4 {
+		t.Fatalf("Expected 4 alarms to be set, got %q", got)
+	}
+
+	code, stdout, _ := runTest(t, "", "plan", "pause", "--from", "2025-01-03")
+	if code != 0 || !strings.Contains(stdout, "Dismissed 2 alarms via fake.") {
+		t.Errorf("Expected pausing to dismiss the alarms from the pause on, got %d:\n%s", code, stdout)
+	}
+	if got := alarmLabels(fake.alarms); !slices.Equal(got, []string{"Sleep Adjustment Wake Up: Wed, Jan 1", "Sleep Adjustment Wake Up: Thu, Jan 2"}) {
+		t.Errorf("Unexpected alarms after pausing %q", got)
+	}
+
+	// Plans are named after the time they were made.
+	timeNow = func() time.Time { return time.Date(2024, 12, 31, 13, 0, 0, 0, time.UTC) }
+	code, stdout, _ = runTest(t, "", "plan", "new", "09:00", "--target", "08:00", "--adjustment", "30m", "--start-date", "2025-01-01", "--yes")
+	if code != 0 || !strings.Contains(stdout, "Dismissed 2 alarms via fake.") || len(fake.alarms) != 0 {
+		t.Errorf("Expected replacing the plan to dismiss its alarms, got %d, %q:\n%s", code, alarmLabels(fake.alarms), stdout)
+	}
+
+	runTest(t, "", "alarms", "sync")
+	timeNow = func() time.Time { return time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC) }
+	code, stdout, _ = runTest(t, "", "alarms", "sync")
+	if code != 0 || !strings.Contains(stdout, "The plan has finished") || len(fake.alarms) != 0 {
+		t.Errorf("Expected syncing a finished plan to dismiss its alarms, got %d, %q:\n%s", code, alarmLabels(fake.alarms), stdout)
+	}
+	if created, _ := loadCreatedAlarms(); len(created) != 0 {
+		t.Errorf("Expected dismissed alarms to be forgotten, got %+v", created)
+	}
+}
+
+func TestAlarmsClear(t *testing.T) {
+	setConfigDir(t.TempDir())
+	fake := useFakeBackend(t)
+	at := atClock(testDate("2025-07-14"), testClock("07:00"))
+	fake.alarms = []Alarm{{Time: at, Label: "a"}, {Time: at, Label: "b"}}
+	saveCreatedAlarms([]CreatedAlarm{
+		{Target: alarmTarget{Backend: "fake"}, Plan: "plan", Alarm: fake.alarms[0]},
+		{Target: alarmTarget{Backend: "fake"}, Plan: "plan", Alarm: fake.alarms[1]},
+		{Target: alarmTarget{Backend: "gone", Device: "R58M123"}, Plan: "plan", Alarm: fake.alarms[0]},
+	})
+
+	code, stdout, stderr := runTest(t, "", "alarms", "clear")
+	if code != 1 || !strings.Contains(stdout, "Dismissed 2 alarms via fake.") || !strings.Contains(stdout, "Could not reach gone R58M123") || !strings.Contains(stderr, "1 alarms could not be dismissed") {
+		t.Errorf("Unexpected clear, got %d:\n%s%s", code, stdout, stderr)
+	}
+	created, _ := loadCreatedAlarms()
+	if len(fake.alarms) != 0 || len(created) != 1 || created[0].Target.Backend != "gone" {
+		t.Errorf("Expected only the unreachable alarm to be kept, got %+v", created)
+	}
+
+	if code, stdout, _ := runTest(t, "", "alarms", "clear", "--device", "emulator-5554"); code != 0 || !strings.Contains(stdout, "No alarms to clear.") {
+		t.Errorf("Expected nothing to clear on another device, got %d:\n%s", code, stdout)
+	}
+}
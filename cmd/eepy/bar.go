@@ -0,0 +1,148 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// sleep waits between updates in watch mode. Tests replace it.
+var sleep = time.Sleep
+
+// Bar classes, from relaxed to urgent. Waybar exposes them to CSS.
+const (
+	barClassIdle    = "idle"
+	barClassAwake   = "awake"
+	barClassSoon    = "soon"
+	barClassBedtime = "bedtime"
+)
+
+// barColors are used by the bars that take a colour instead of a class.
+var barColors = map[string]string{
+	barClassSoon:    "#ffb86c",
+	barClassBedtime: "#ff5555",
+}
+
+// barClass is how urgent going to bed is: soon within warn of bedtime and
+// bedtime once it has passed. Paused and upcoming plans are idle.
+func barClass(s Status, warn time.Duration) string {
+	switch {
+	case s.State == statePaused || s.State == stateUpcoming:
+		return barClassIdle
+	case s.PastBedtime:
+		return barClassBedtime
+	case time.Duration(s.MinutesUntilBedtime)*time.Minute <= warn:
+		return barClassSoon
+	}
+	return barClassAwake
+}
+
+// barText is the short text shown in the bar.
+func barText(s Status) string {
+	if s.State == statePaused {
+		return tr.T("eepy paused")
+	}
+	return tr.Sprintf("bed %s %s", tr.clockString(s.Bedtime), s.bedtimeDistance())
+}
+
+// writeBar writes one update of s for the given bar. i3blocks gets its full
+// text, short text and colour lines, or only the full text when watching.
+func writeBar(w io.Writer, format string, s Status, warn time.Duration, watching bool) error {
+	class := barClass(s, warn)
+	text := barText(s)
+	switch format {
+	case "waybar":
+		var tooltip bytes.Buffer
+		displayStatus(&tooltip, s)
+		data, err := json.Marshal(struct {
+			Text    string `json:"text"`
+			Tooltip string `json:"tooltip"`
+			Class   string `json:"class"`
+		}{text, string(bytes.TrimSpace(tooltip.Bytes())), class})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "i3blocks":
+		if watching {
+			_, err := fmt.Fprintln(w, text)
+			return err
+		}
+		_, err := fmt.Fprintf(w, "%s\n%s\n%s\n", text, tr.clockString(s.Bedtime), barColors[class])
+		return err
+	case "polybar":
+		if color, ok := barColors[class]; ok {
+			text = fmt.Sprintf("%%{F%s}%s%%{F-}", color, text)
+		}
+		_, err := fmt.Fprintln(w, text)
+		return err
+	}
+	return tr.Errorf("unknown bar format %q", format)
+}
+
+// writeNoPlan writes an update for when there is no plan to show, with the
+// reason in the waybar tooltip.
+func writeNoPlan(w io.Writer, format string, reason error) error {
+	text := tr.T("eepy: no plan")
+	if format == "waybar" {
+		data, err := json.Marshal(struct {
+			Text    string `json:"text"`
+			Tooltip string `json:"tooltip"`
+			Class   string `json:"class"`
+		}{text, reason.Error(), barClassIdle})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	_, err := fmt.Fprintln(w, text)
+	return err
+}
+
+func barCommand(flags *pflag.FlagSet) runFunc {
+	format := flags.StringP("format", "f", "waybar", "Bar format (waybar, i3blocks or polybar)")
+	warn := flags.Duration("warn", time.Hour, "How long before bedtime the bar turns to the soon class")
+	configurable(flags, "warn")
+	watch := flags.Duration("watch", 0, "Print an update at this interval instead of once")
+	return func(a *app, args []string) error {
+		if *watch < 0 {
+			return tr.Errorf("invalid watch interval %s", *watch)
+		}
+		if !slices.Contains([]string{"waybar", "i3blocks", "polybar"}, *format) {
+			return tr.Errorf("unknown bar format %q", *format)
+		}
+		for {
+			p, err := loadActivePlan()
+			switch {
+			case err != nil && *watch == 0:
+				return err
+			case err != nil:
+				// The plan may be gone or halfway through being saved, so
+				// the bar says so until the next update.
+				err = writeNoPlan(a.stdout, *format, err)
+			default:
+				err = writeBar(a.stdout, *format, computeStatus(p, timeNow()), *warn, *watch > 0)
+			}
+			if err != nil {
+				return err
+			}
+			if *watch == 0 {
+				return nil
+			}
+			sleep(*watch)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBarClass(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   string
+	}{
+		{Status{State: stateActive, MinutesUntilBedtime: 120}, barClassAwake},
+		{Status{State: stateActive, MinutesUntilBedtime: 60}, barClassSoon},
+		{Status{State: stateActive, MinutesUntilBedtime: -5, PastBedtime: true}, barClassBedtime},
+		{Status{State: statePaused, MinutesUntilBedtime: -5, PastBedtime: true}, barClassIdle},
+	}
+	for _, test := range tests {
+		if got := barClass(test.status, time.Hour); got != test.want {
+			t.Errorf("%+v: expected %s, got %s", test.status, test.want, got)
+		}
+	}
+}
+
+func TestWriteBar(t *testing.T) {
+	s := Status{State: stateActive, Day: 1, Days: 3, Bedtime: "22:30", NextWake: "07:30", MinutesUntilBedtime: 30}
+
+	var b bytes.Buffer
+	if err := writeBar(&b, "waybar", s, time.Hour, false); err != nil {
+		t.Fatal(err)
+	}
+	var waybar struct{ Text, Tooltip, Class string }
+	if err := json.Unmarshal(b.Bytes(), &waybar); err != nil || waybar.Text != "bed 22:30 in 30m" || waybar.Class != barClassSoon || waybar.Tooltip == "" {
+		t.Errorf("Unexpected waybar output %q (%v)", b.String(), err)
+	}
+
+	tests := []struct {
+		format   string
+		watching bool
+		want     string
+	}{
+		{"i3blocks", false, "bed 22:30 in 30m\n22:30\n#ffb86c\n"},
+		{"i3blocks", true, "bed 22:30 in 30m\n"},
+		{"polybar", false, "%{F#ffb86c}bed 22:30 in 30m%{F-}\n"},
+	}
+	for _, test := range tests {
+		b.Reset()
+		if err := writeBar(&b, test.format, s, time.Hour, test.watching); err != nil || b.String() != test.want {
+			t.Errorf("%s: expected %q, got %q (%v)", test.format, test.want, b.String(), err)
+		}
+	}
+}
+
+// failingWriter fails once it has been written to n times.
+// failingWriter keeps what is written to it until it has taken n writes,
+// and then fails like a bar that went away.
+type failingWriter struct {
+	n       int
+	written bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("closed")
+	}
+	w.n--
+	return w.written.Write(p)
+}
+
+func TestBarWatch(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if code, _, stderr := runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-07-13"); code != 0 {
+		t.Fatalf("plan new failed: %s", stderr)
+	}
+
+	slept := 0
+	sleep = func(d time.Duration) { slept++ }
+	defer func() { sleep = time.Sleep }()
+
+	var stderr bytes.Buffer
+	a := &app{stdout: &failingWriter{n: 3}, stderr: &stderr}
+	if code := a.exec([]string{"bar", "--format", "polybar", "--watch", "1m"}); code != 1 || slept != 3 {
+		t.Errorf("Expected watching to stop when the bar goes away after 3 updates, got %d after %d sleeps", code, slept)
+	}
+}
+
+func TestBarWatchWithoutPlan(t *testing.T) {
+	setConfigDir(t.TempDir())
+	sleep = func(d time.Duration) {
+		runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-07-13")
+	}
+	defer func() { sleep = time.Sleep }()
+
+	if code, _, _ := runTest(t, "", "bar"); code != 1 {
+		t.Errorf("Expected a single update without a plan to fail, got %d", code)
+	}
+	var stderr bytes.Buffer
+	out := &failingWriter{n: 2}
+	a := &app{stdout: out, stderr: &stderr}
+	if code := a.exec([]string{"bar", "--format", "polybar", "--watch", "1m"}); code != 1 {
+		t.Errorf("Expected watching to go on until the bar goes away, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out.written.String()), "\n")
+	if len(lines) != 2 || lines[0] != "eepy: no plan" || !strings.Contains(lines[1], "bed ") {
+		t.Errorf("Expected a no plan update and then the plan, got %q", lines)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DeviceSetting is a setting of an Android device in the global, secure or
+// system namespace of the settings command. A Value of "null" means that
+// the setting is not set.
+type DeviceSetting struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+}
+
+// zenModes are the values of the zen_mode setting by the names that the dnd
+// setting and `cmd notification set_dnd` use.
+var zenModes = map[string]string{"off": "0", "priority": "1", "none": "2", "alarms": "3"}
+
+func checkDND(s string) error {
+	if _, ok := zenModes[s]; !ok {
+		return tr.Errorf("unknown Do Not Disturb mode %q; choose one of %s", s, strings.Join(slices.Sorted(maps.Keys(zenModes)), ", "))
+	}
+	return nil
+}
+
+// bedtimeChanges returns the settings of bedtime mode: the Do Not Disturb
+// mode dnd unless it is off, and, for grayscale, the colour correction for
+// monochromacy.
+func bedtimeChanges(dnd string, grayscale bool) []DeviceSetting {
+	var changes []DeviceSetting
+	if dnd != "off" {
+		changes = append(changes, DeviceSetting{"global", "zen_mode", zenModes[dnd]})
+	}
+	if grayscale {
+		changes = append(changes,
+			DeviceSetting{"secure", "accessibility_display_daltonizer_enabled", "1"},
+			DeviceSetting{"secure", "accessibility_display_daltonizer", "0"},
+		)
+	}
+	return changes
+}
+
+// BedtimeMode is a device that eepy has put in bedtime mode, with the
+// values its settings had before, so that they can be restored.
+type BedtimeMode struct {
+	Device   string          `json:"device"`
+	Original []DeviceSetting `json:"original"`
+}
+
+func loadBedtimeModes() ([]BedtimeMode, error) {
+	data, err := os.ReadFile(bedtimeModePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var modes []BedtimeMode
+	if err := json.Unmarshal(data, &modes); err != nil {
+		return nil, tr.Errorf("error reading bedtime mode: %w", err)
+	}
+	return modes, nil
+}
+
+func saveBedtimeModes(modes []BedtimeMode) error {
+	data, err := json.MarshalIndent(modes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(bedtimeModePath, data, 0644)
+}
+
+// inBedtime reports whether now falls between a bedtime of p and the wake
+// up time after it, on a day that is not paused.
+func inBedtime(p *Plan, now time.Time) bool {
+	now = wallClock(now, p.StartDate.Location())
+	return slices.ContainsFunc(p.Days(), func(d Day) bool {
+		return !p.InPause(d.Date) && !now.Before(d.Bedtime) && now.Before(d.Wake)
+	})
+}
+
+// deviceSettings reads and changes the settings of the device with the
+// given serial.
+type deviceSettings struct {
+	serial string
+	shell  func(command string) (string, error)
+}
+
+func newDeviceSettings(client *adbClient, serial string) deviceSettings {
+	return deviceSettings{serial: serial, shell: func(command string) (string, error) {
+		return client.Shell(serial, command)
+	}}
+}
+
+// run runs a command that prints nothing unless it fails.
+func (d deviceSettings) run(command string) error {
+	output, err := d.shell(command)
+	if err != nil {
+		return err
+	}
+	if output = strings.TrimSpace(output); output != "" {
+		return tr.Errorf("%s: %s", command, output)
+	}
+	return nil
+}
+
+// get returns s with the value the device has for it.
+func (d deviceSettings) get(s DeviceSetting) (DeviceSetting, error) {
+	output, err := d.shell("settings get " + s.Namespace + " " + s.Key)
+	if err != nil {
+		return s, err
+	}
+	s.Value = strings.TrimSpace(output)
+	return s, nil
+}
+
+// put changes a setting to s.Value, or deletes it if that is "null". Do Not
+// Disturb is changed through the notification service, which would undo a
+// change to zen_mode made behind its back.
+func (d deviceSettings) put(s DeviceSetting) error {
+	if s.Namespace == "global" && s.Key == "zen_mode" {
+		name := "off"
+		for n, value := range zenModes {
+			if value == s.Value {
+				name = n
+			}
+		}
+		return d.run("cmd notification set_dnd " + name)
+	}
+	if s.Value == "null" {
+		// settings delete reports how many rows it deleted.
+		_, err := d.shell("settings delete " + s.Namespace + " " + s.Key)
+		return err
+	}
+	return d.run("settings put " + s.Namespace + " " + s.Key + " " + s.Value)
+}
+
+// enter records the current values of the settings in changes as mode and
+// then applies the changes. The values are saved first, so that restoring
+// works even if applying stops halfway.
+func (d deviceSettings) enter(modes *[]BedtimeMode, changes []DeviceSetting) error {
+	mode := BedtimeMode{Device: d.serial}
+	for _, change := range changes {
+		original, err := d.get(change)
+		if err != nil {
+			return err
+		}
+		mode.Original = append(mode.Original, original)
+	}
+	*modes = append(*modes, mode)
+	if err := saveBedtimeModes(*modes); err != nil {
+		return tr.Errorf("error saving bedtime mode: %w", err)
+	}
+	for _, change := range changes {
+		if err := d.put(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leave puts back the original settings of mode.
+func (d deviceSettings) leave(mode BedtimeMode) error {
+	for _, original := range mode.Original {
+		if err := d.put(original); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bedtimeSyncCommand(flags *pflag.FlagSet) runFunc {
+	devices := flags.StringSlice("device", nil, "Serial or alias of the device to switch; repeat it or pass 'all' for several")
+	dnd := flags.String("dnd", "priority", "Do Not Disturb mode at night: priority, alarms, none or off")
+	grayscale := flags.Bool("grayscale", true, "Turn the screen gray at night")
+	configurable(flags, "device", "dnd", "grayscale")
+	return func(a *app, args []string) error {
+		if err := checkDND(*dnd); err != nil {
+			return err
+		}
+		changes := bedtimeChanges(*dnd, *grayscale)
+		if len(changes) == 0 {
+			return tr.Errorf("bedtime mode would change nothing; choose a dnd mode or grayscale")
+		}
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		serials, err := a.deviceSerials(*devices)
+		if err != nil {
+			return err
+		}
+		client := newADBClient(a.getenv)
+		available, err := client.Devices()
+		if err != nil {
+			return err
+		}
+		chosen, err := chooseDevices(serials, available)
+		if err != nil {
+			return err
+		}
+		modes, err := loadBedtimeModes()
+		if err != nil {
+			return err
+		}
+
+		night := inBedtime(p, timeNow())
+		var failed []string
+		for _, device := range chosen {
+			d := newDeviceSettings(client, device.Serial)
+			i := slices.IndexFunc(modes, func(m BedtimeMode) bool { return m.Device == d.serial })
+			switch {
+			case night && i >= 0:
+				tr.Fprintf(a.stdout, "%s is already in bedtime mode.\n", d.serial)
+			case night:
+				if err := d.enter(&modes, changes); err != nil {
+					tr.Fprintf(a.stdout, "Error switching %s: %v\n", d.serial, err)
+					failed = append(failed, d.serial)
+					continue
+				}
+				tr.Fprintf(a.stdout, "Switched %s to bedtime mode.\n", d.serial)
+			case i >= 0:
+				if err := d.leave(modes[i]); err != nil {
+					tr.Fprintf(a.stdout, "Error switching %s: %v\n", d.serial, err)
+					failed = append(failed, d.serial)
+					continue
+				}
+				modes = slices.Delete(modes, i, i+1)
+				tr.Fprintf(a.stdout, "Switched %s back from bedtime mode.\n", d.serial)
+			default:
+				tr.Fprintf(a.stdout, "It is not bedtime; %s is left as it is.\n", d.serial)
+			}
+		}
+		if err := saveBedtimeModes(modes); err != nil {
+			return tr.Errorf("error saving bedtime mode: %w", err)
+		}
+		if len(failed) > 0 {
+			return tr.Errorf("bedtime mode could not be switched on %s; run 'eepy bedtime restore' to undo any changes", strings.Join(failed, ", "))
+		}
+		return nil
+	}
+}
+
+func bedtimeRestoreCommand(flags *pflag.FlagSet) runFunc {
+	devices := flags.StringSlice("device", nil, "Only restore these devices (serial or alias)")
+	return func(a *app, args []string) error {
+		serials, err := a.deviceSerials(*devices)
+		if err != nil {
+			return err
+		}
+		modes, err := loadBedtimeModes()
+		if err != nil {
+			return err
+		}
+		match := func(m BedtimeMode) bool { return len(serials) == 0 || slices.Contains(serials, m.Device) }
+		if !slices.ContainsFunc(modes, match) {
+			tr.Fprintln(a.stdout, "No device settings to restore.")
+			return nil
+		}
+		client := newADBClient(a.getenv)
+		var failed []string
+		modes = slices.DeleteFunc(modes, func(m BedtimeMode) bool {
+			if !match(m) {
+				return false
+			}
+			if err := newDeviceSettings(client, m.Device).leave(m); err != nil {
+				tr.Fprintf(a.stdout, "Error switching %s: %v\n", m.Device, err)
+				failed = append(failed, m.Device)
+				return false
+			}
+			tr.Fprintf(a.stdout, "Restored the settings of %s.\n", m.Device)
+			return true
+		})
+		if err := saveBedtimeModes(modes); err != nil {
+			return tr.Errorf("error saving bedtime mode: %w", err)
+		}
+		if len(failed) > 0 {
+			return tr.Errorf("the settings of %s could not be restored; connect it and try again", strings.Join(failed, ", "))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"maps"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInBedtime(t *testing.T) {
+	p := testPlan(t)
+	bedtime := p.Days()[1].Bedtime
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{bedtime.Add(-time.Minute), false},
+		{bedtime, true},
+		{p.Days()[1].Wake.Add(-time.Minute), true},
+		{p.Days()[1].Wake, false},
+	}
+	for _, test := range tests {
+		if got := inBedtime(p, test.now); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.now, test.want, got)
+		}
+	}
+	p.Pauses = []Pause{{Start: p.Days()[1].Date}}
+	if inBedtime(p, bedtime) {
+		t.Errorf("Expected no bedtime mode on a paused day")
+	}
+
+	if err := checkDND("dark"); err == nil {
+		t.Errorf("Expected an unknown Do Not Disturb mode to be refused")
+	}
+	if changes := bedtimeChanges("off", false); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestBedtimeMode(t *testing.T) {
+	setConfigDir(t.TempDir())
+	server := startFakeADBServer(t, "R58M123\tdevice\n")
+	original := map[string]string{"global zen_mode": "0", "secure accessibility_display_daltonizer": "11"}
+	settings := maps.Clone(original)
+	var mu sync.Mutex
+	server.shell = func(serial, command string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		fields := strings.Fields(command)
+		switch {
+		case fields[0] == "cmd":
+			settings["global zen_mode"] = zenModes[fields[3]]
+		case fields[1] == "get":
+			if value, ok := settings[fields[2]+" "+fields[3]]; ok {
+				return value + "\n"
+			}
+			return "null\n"
+		case fields[1] == "put":
+			settings[fields[2]+" "+fields[3]] = fields[4]
+		case fields[1] == "delete":
+			delete(settings, fields[2]+" "+fields[3])
+			return "Deleted 1 rows\n"
+		}
+		return ""
+	}
+	current := func() map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return maps.Clone(settings)
+	}
+	_, port, _ := net.SplitHostPort(server.addr)
+	t.Setenv("ANDROID_ADB_SERVER_PORT", port)
+	at := func(date, clock string) {
+		timeNow = func() time.Time { return atClock(testDate(date), testClock(clock)) }
+	}
+	defer func() { timeNow = time.Now }()
+
+	at("2025-07-13", "12:00")
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:00", "--adjustment", "1h", "--start-date", "2025-07-13")
+	at("2025-07-13", "21:00")
+	if code, stdout, _ := runTest(t, "", "bedtime", "sync"); code != 0 || !strings.Contains(stdout, "It is not bedtime") {
+		t.Errorf("Expected nothing to change before bedtime, got %d: %s", code, stdout)
+	}
+
+	at("2025-07-13", "22:30")
+	code, stdout, stderr := runTest(t, "", "bedtime", "sync")
+	want := map[string]string{"global zen_mode": "1", "secure accessibility_display_daltonizer_enabled": "1", "secure accessibility_display_daltonizer": "0"}
+	if code != 0 || !strings.Contains(stdout, "Switched R58M123 to bedtime mode.") || !maps.Equal(current(), want) {
+		t.Errorf("Expected bedtime mode at bedtime, got %d, %v: %s%s", code, current(), stdout, stderr)
+	}
+	if _, stdout, _ := runTest(t, "", "bedtime", "sync"); !strings.Contains(stdout, "already in bedtime mode") {
+		t.Errorf("Expected syncing again to change nothing, got %s", stdout)
+	}
+
+	at("2025-07-14", "07:30")
+	if code, stdout, _ := runTest(t, "", "bedtime", "sync"); code != 0 || !strings.Contains(stdout, "Switched R58M123 back") || !maps.Equal(current(), original) {
+		t.Errorf("Expected the settings back after waking up, got %d, %v: %s", code, current(), stdout)
+	}
+
+	at("2025-07-14", "21:30")
+	runTest(t, "", "bedtime", "sync", "--dnd", "alarms", "--grayscale=false")
+	if got := current()["global zen_mode"]; got != "3" {
+		t.Errorf("Expected Do Not Disturb for alarms only, got %s", got)
+	}
+	if code, stdout, _ := runTest(t, "", "bedtime", "restore"); code != 0 || !strings.Contains(stdout, "Restored the settings of R58M123.") || !maps.Equal(current(), original) {
+		t.Errorf("Expected restore to put the settings back, got %d, %v: %s", code, current(), stdout)
+	}
+	if _, stdout, _ := runTest(t, "", "bedtime", "restore"); !strings.Contains(stdout, "No device settings to restore.") {
+		t.Errorf("Expected nothing left to restore, got %s", stdout)
+	}
+}
@@ -0,0 +1,349 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/pflag"
+)
+
+// app carries what a command needs to talk to the outside world, so commands
+// can be run and inspected from tests.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	prompt prompter
+	getenv func(string) string
+
+	assumeYes bool
+	noInput   bool
+}
+
+func newApp(stdin io.Reader, stdout, stderr io.Writer) *app {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr, getenv: os.Getenv}
+	if isTerminal(stdin) {
+		a.prompt = newLinePrompter(stdin, stdout)
+	}
+	return a
+}
+
+// env returns the environment variable key, or nothing if the app has no
+// environment.
+func (a *app) env(key string) string {
+	if a.getenv == nil {
+		return ""
+	}
+	return a.getenv(key)
+}
+
+// runFunc runs a command with the arguments left after parsing its flags.
+type runFunc func(a *app, args []string) error
+
+// command is a node in the command tree. Groups have subcommands, leaves
+// have a setup function that defines their flags and returns the function
+// that runs them.
+type command struct {
+	name        string
+	aliases     []string
+	usage       string
+	short       string
+	subcommands []*command
+	setup       func(flags *pflag.FlagSet) runFunc
+}
+
+var rootCommand = &command{
+	name:  "eepy",
+	short: "Calibrate your sleep schedule",
+	subcommands: []*command{
+		{
+			name:  "plan",
+			short: "Create, show and change your sleep plan",
+			subcommands: []*command{
+				{name: "new", usage: "<wake-time> [flags]", short: "Create a plan from your current wake up time", setup: planNewCommand},
+				{name: "show", short: "Show the active plan", setup: planShowCommand},
+				{name: "edit", usage: "<date> [flags]", short: "Override a single day of the plan", setup: editCommand},
+				{name: "replan", short: "Regenerate the plan from new parameters, keeping edits", setup: replanCommand},
+				{name: "pause", short: "Put the plan on hold", setup: pauseCommand},
+				{name: "resume", short: "Continue a paused plan", setup: resumeCommand},
+				{name: "undo", short: "Restore the previous revision of the plan", setup: undoCommand},
+				{name: "redo", short: "Reapply a revision that was undone", setup: redoCommand},
+			},
+		},
+		{
+			name:  "alarms",
+			short: "Manage wake up alarms",
+			subcommands: []*command{
+				{name: "sync", short: "Set alarms for the active plan", setup: alarmsSyncCommand},
+				{name: "verify", short: "Check that the alarms on the device match the plan", setup: alarmsVerifyCommand},
+				{name: "clear", short: "Dismiss the alarms eepy has set", setup: alarmsClearCommand},
+				{name: "devices", short: "List the devices alarms can be set on", setup: alarmsDevicesCommand},
+			},
+		},
+		{
+			name:  "bedtime",
+			short: "Switch devices to Do Not Disturb and grayscale at night",
+			subcommands: []*command{
+				{name: "sync", short: "Switch devices into or out of bedtime mode for the time of night", setup: bedtimeSyncCommand},
+				{name: "restore", short: "Restore the settings bedtime mode changed", setup: bedtimeRestoreCommand},
+			},
+		},
+		{
+			name:  "report",
+			short: "Generate reports about the plan",
+			subcommands: []*command{
+				{name: "html", short: "Generate and open an HTML visualization of the plan", setup: reportHTMLCommand},
+			},
+		},
+		{
+			name:  "log",
+			short: "Keep a diary of how you slept",
+			subcommands: []*command{
+				{name: "add", short: "Record a night", setup: logAddCommand},
+				{name: "list", short: "List recorded nights", setup: logListCommand},
+				{name: "correct", usage: "<entry-id> [flags]", short: "Correct a recorded night", setup: logCorrectCommand},
+				{name: "delete", usage: "<entry-id>", short: "Delete a recorded night", setup: logDeleteCommand},
+			},
+		},
+		{name: "now", aliases: []string{"status"}, short: "Show today's wake up time, tonight's bedtime and how long until it", setup: nowCommand},
+		{name: "bar", short: "Print the bedtime countdown for waybar, i3blocks or polybar", setup: barCommand},
+		{name: "tui", usage: "[wake-time]", short: "Try out plans in a full-screen editor", setup: tuiCommand},
+		{name: "stats", short: "Compare your diary with the plan", setup: statsCommand},
+		{name: "history", usage: "[number]", short: "List replaced plans or show one of them", setup: historyCommand},
+		{
+			name:  "config",
+			short: "Inspect and change eepy's configuration",
+			subcommands: []*command{
+				{name: "list", short: "List the settings, their values and where they come from", setup: configListCommand},
+				{name: "get", usage: "<setting>", short: "Show the value of a setting", setup: configGetCommand},
+				{name: "set", usage: "<setting> <value>", short: "Change a setting in the config file", setup: configSetCommand},
+				{name: "unset", usage: "<setting>", short: "Remove a setting from the config file", setup: configUnsetCommand},
+				{name: "path", short: "Show where eepy keeps its files", setup: configPathCommand},
+			},
+		},
+	},
+}
+
+func (c *command) find(name string) *command {
+	for _, sub := range c.subcommands {
+		if sub.name == name || slices.Contains(sub.aliases, name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// names is the name of the command followed by its aliases.
+func (c *command) names() string {
+	return strings.Join(append([]string{c.name}, c.aliases...), ", ")
+}
+
+// run executes the command line args and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	return newApp(stdin, stdout, stderr).exec(args)
+}
+
+func (a *app) exec(args []string) int {
+	if err := a.run(args); err != nil {
+		tr.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// globalFlags adds the flags every command accepts.
+func (a *app) globalFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&a.assumeYes, "yes", "y", false, "Answer yes to every confirmation prompt")
+	flags.BoolVar(&a.assumeYes, "force", false, "Same as --yes")
+	flags.BoolVar(&a.noInput, "no-input", false, "Never prompt; fail if confirmation is needed")
+}
+
+func (a *app) run(args []string) error {
+	if isLegacyInvocation(args) {
+		return runLegacy(a, args)
+	}
+	if args[0] == "help" {
+		return a.help(args[1:])
+	}
+
+	cmd, path := rootCommand, []string{rootCommand.name}
+	for len(args) > 0 && cmd.subcommands != nil {
+		sub := cmd.find(args[0])
+		if sub == nil {
+			break
+		}
+		cmd, path, args = sub, append(path, sub.name), args[1:]
+	}
+
+	if cmd.setup == nil {
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+			printUsage(a.stdout, cmd, path, nil)
+			return nil
+		}
+		printUsage(a.stderr, cmd, path, nil)
+		return tr.Errorf("unknown command %q", strings.Join(append(path[1:], args[0]), " "))
+	}
+
+	flags := pflag.NewFlagSet(strings.Join(path, " "), pflag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	runCommand := cmd.setup(flags)
+	a.globalFlags(flags)
+	flags.Usage = func() { printUsage(flags.Output(), cmd, path, flags) }
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := a.applySettings(flags); err != nil {
+		return err
+	}
+	return runCommand(a, flags.Args())
+}
+
+// help prints the usage of the command named by args.
+func (a *app) help(args []string) error {
+	cmd, path := rootCommand, []string{rootCommand.name}
+	for _, name := range args {
+		sub := cmd.find(name)
+		if sub == nil {
+			return tr.Errorf("unknown command %q", strings.Join(append(path[1:], name), " "))
+		}
+		cmd, path = sub, append(path, sub.name)
+	}
+	var flags *pflag.FlagSet
+	if cmd.setup != nil {
+		flags = pflag.NewFlagSet(strings.Join(path, " "), pflag.ContinueOnError)
+		cmd.setup(flags)
+		a.globalFlags(flags)
+	}
+	printUsage(a.stdout, cmd, path, flags)
+	return nil
+}
+
+func printUsage(w io.Writer, cmd *command, path []string, flags *pflag.FlagSet) {
+	name := strings.Join(path, " ")
+	switch {
+	case cmd.setup == nil:
+		tr.Fprintf(w, "Usage: %s <command>\n\n", name)
+	case cmd.usage != "":
+		tr.Fprintf(w, "Usage: %s %s\n\n", name, cmd.usage)
+	default:
+		tr.Fprintf(w, "Usage: %s [flags]\n\n", name)
+	}
+	fmt.Fprintf(w, "%s.\n", tr.T(cmd.short))
+
+	if len(cmd.subcommands) > 0 {
+		tr.Fprintln(w, "\nCommands:")
+		width := 0
+		for _, sub := range cmd.subcommands {
+			width = max(width, len(sub.names()))
+		}
+		for _, sub := range cmd.subcommands {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, sub.names(), tr.T(sub.short))
+		}
+		tr.Fprintf(w, "\nRun '%s help <command>' for more about a command.\n", path[0])
+	}
+	if cmd == rootCommand {
+		tr.Fprintln(w, "\nThe older form 'eepy [wake-time] [flags]' still creates or shows a plan.")
+	}
+	if flags != nil && flags.HasFlags() {
+		translateUsages(flags)
+		tr.Fprintf(w, "\nFlags:\n%s", flags.FlagUsages())
+	}
+}
+
+// translateUsages translates the usage of each flag for the help.
+func translateUsages(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) { f.Usage = tr.T(f.Usage) })
+}
+
+// isLegacyInvocation reports whether args use the command line from before
+// eepy had subcommands: an optional wake up time followed by flags.
+func isLegacyInvocation(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	first := args[0]
+	if first == "-h" || first == "--help" {
+		return false
+	}
+	if strings.HasPrefix(first, "-") {
+		return true
+	}
+	return rootCommand.find(first) == nil && first != "help" && len(first) > 0 && unicode.IsDigit(rune(first[0]))
+}
+
+// runLegacy keeps `eepy [wake-time] [flags]` working.
+func runLegacy(a *app, args []string) error {
+	flags := pflag.NewFlagSet("eepy", pflag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	runCommand := legacyCommand(flags)
+	a.globalFlags(flags)
+	flags.Usage = func() {
+		tr.Fprintln(flags.Output(), "Usage: eepy [wake-time] [flags]")
+		translateUsages(flags)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := a.applySettings(flags); err != nil {
+		return err
+	}
+	return runCommand(a, flags.Args())
+}
+
+// legacyCommand creates a plan when given a wake up time and shows the
+// active plan otherwise, then generates an HTML report and sets alarms when
+// asked to.
+func legacyCommand(flags *pflag.FlagSet) runFunc {
+	newPlan := planFlags(flags)
+	adb := flags.BoolP("adb", "a", false, "Set alarms with the alarm backend, ADB unless configured otherwise")
+	alarms := newAlarmFlags(flags)
+	htmlOutput := flags.Bool("html", false, "Generate an HTML visualization of the plan")
+	noOpen := flags.Bool("no-open", false, "Do not open the HTML report")
+	configurable(flags, "adb", "no-open")
+	return func(a *app, args []string) error {
+		var p *Plan
+		var err error
+		if len(args) == 0 {
+			p, err = loadPlan()
+			if err != nil {
+				tr.Fprintln(a.stderr, "No active sleep plan found. Create one by providing a wake-up time.")
+				flags.Usage()
+				return errNoPlan
+			}
+			displayPlan(a.stdout, p)
+		} else {
+			p, err = newPlan(a, args[0])
+			if err != nil || p == nil {
+				return err
+			}
+		}
+
+		if *htmlOutput {
+			if err := generateHTML(a.stdout, p, !*noOpen); err != nil {
+				tr.Fprintf(a.stderr, "Error generating HTML: %v\n", err)
+			}
+		}
+		if *adb {
+			return alarms.sync(a, p)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runTest runs eepy with args and returns its exit code and output.
+func runTest(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunLegacyInvocation(t *testing.T) {
+	setConfigDir(t.TempDir())
+
+	code, _, stderr := runTest(t, "")
+	if code != 1 || !strings.Contains(stderr, "No active sleep plan found") {
+		t.Errorf("Expected exit 1 without a plan, got %d: %s", code, stderr)
+	}
+
+	code, stdout, _ := runTest(t, "", "10:00", "--target", "09:00", "--adjustment", "30m", "--start-date", "2025-01-01")
+	if code != 0 || !strings.Contains(stdout, "Wed, Jan 1 (Day 1):") || !strings.Contains(stdout, "Wake up at 09:30") {
+		t.Errorf("Expected the legacy form to create a plan, got %d: %s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "")
+	if code != 0 || !strings.Contains(stdout, "Fri, Jan 3 (Day 3):") {
+		t.Errorf("Expected no arguments to show the plan, got %d: %s", code, stdout)
+	}
+}
+
+func TestRunPlanCommands(t *testing.T) {
+	setConfigDir(t.TempDir())
+
+	if code, _, stderr := runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-07-13"); code != 0 {
+		t.Fatalf("plan new failed: %s", stderr)
+	}
+
+	code, _, stderr := runTest(t, "y\n", "plan", "new", "09:00")
+	if code != 1 || !strings.Contains(stderr, "pass --yes to confirm") {
+		t.Errorf("Expected piped input not to answer the override prompt, got %d: %s", code, stderr)
+	}
+
+	code, stdout, _ := runTest(t, "", "plan", "edit", "2025-07-14", "--wake", "06:00")
+	if code != 0 || !strings.Contains(stdout, "Wake up at 06:00 (edited)") {
+		t.Errorf("Expected plan edit to override the wake up time, got %d: %s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "plan", "undo")
+	if code != 0 || !strings.Contains(stdout, "Undid edit") || strings.Contains(stdout, "(edited)") {
+		t.Errorf("Expected plan undo to drop the edit, got %d: %s", code, stdout)
+	}
+
+	// The edit moves with its day when a day is inserted before it, and
+	// --clear finds it on the day's new date.
+	runTest(t, "", "plan", "edit", "2025-07-14", "--wake", "06:00")
+	runTest(t, "", "plan", "edit", "2025-07-13", "--insert")
+	code, stdout, _ = runTest(t, "", "plan", "edit", "2025-07-15", "--clear")
+	if code != 0 || strings.Contains(stdout, "Wake up at 06:00") {
+		t.Errorf("Expected --clear to drop the moved edit, got %d: %s", code, stdout)
+	}
+}
+
+func TestRunHelpAndErrors(t *testing.T) {
+	setConfigDir(t.TempDir())
+
+	code, stdout, _ := runTest(t, "", "help", "plan", "edit")
+	if code != 0 || !strings.Contains(stdout, "Usage: eepy plan edit <date> [flags]") || !strings.Contains(stdout, "--insert") {
+		t.Errorf("Expected help for plan edit, got %d: %s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "log")
+	if code != 0 || !strings.Contains(stdout, "correct") {
+		t.Errorf("Expected a command group to list its commands, got %d: %s", code, stdout)
+	}
+
+	code, _, stderr := runTest(t, "", "plna")
+	if code != 1 || !strings.Contains(stderr, `unknown command "plna"`) {
+		t.Errorf("Expected an unknown command error, got %d: %s", code, stderr)
+	}
+
+	code, _, stderr = runTest(t, "", "plan", "show")
+	if code != 1 || !strings.Contains(stderr, "no active sleep plan") {
+		t.Errorf("Expected plan show to fail without a plan, got %d: %s", code, stderr)
+	}
+
+	code, _, stderr = runTest(t, "", "stats", "--bogus")
+	if code != 1 || !strings.Contains(stderr, "unknown flag: --bogus") {
+		t.Errorf("Expected an unknown flag error, got %d: %s", code, stderr)
+	}
+}
@@ -0,0 +1,313 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+var errNoPlan = translatedError("no active sleep plan found; create one with 'eepy plan new <wake-time>'")
+
+// loadActivePlan loads the active plan, reporting a missing one as errNoPlan.
+func loadActivePlan() (*Plan, error) {
+	p, err := loadPlan()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNoPlan
+	}
+	if err != nil {
+		return nil, tr.Errorf("error loading plan: %w", err)
+	}
+	return p, nil
+}
+
+// planFlags defines the flags that shape a new plan and returns a function
+// that creates it from the current wake up time. Replacing an active plan
+// asks for confirmation first; the returned plan is nil if that is declined.
+func planFlags(flags *pflag.FlagSet) func(a *app, wakeTimeStr string) (*Plan, error) {
+	targetWakeTimeStr := flags.String("target", "05:00", "Your target wake up time (HH:MM, 7am, ...)")
+	adjustmentStr := flags.String("adjustment", "1h30m", "Adjustment per day")
+	sleepNeed := flags.Duration("sleep-need", idealSleepDuration, "How long you want to sleep each night")
+	configurable(flags, "target", "adjustment", "sleep-need")
+	startDateStr := flags.String("start-date", "today", "The start date of the plan (YYYY-MM-DD, tomorrow, mon, +3d, ...)")
+
+	return func(a *app, wakeTimeStr string) (*Plan, error) {
+		startDate, err := parseDateFlag(*startDateStr, time.UTC)
+		if err != nil {
+			return nil, tr.Errorf("error parsing start-date: %w", err)
+		}
+		wakeTime, err := parseClock(wakeTimeStr)
+		if err != nil {
+			return nil, tr.Errorf("error parsing wake-time: %w", err)
+		}
+		targetWakeTime, err := parseClock(*targetWakeTimeStr)
+		if err != nil {
+			return nil, tr.Errorf("error parsing target-wake-time: %w", err)
+		}
+		adjustment, err := time.ParseDuration(*adjustmentStr)
+		if err != nil {
+			return nil, tr.Errorf("error parsing adjustment: %w", err)
+		}
+		if err := checkSleepNeed(*sleepNeed); err != nil {
+			return nil, err
+		}
+
+		if _, err := loadPlan(); err == nil {
+			ok, err := a.confirm(tr.T("An active sleep plan already exists. Do you want to override it?"))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				tr.Fprintln(a.stdout, "Operation cancelled.")
+				return nil, nil
+			}
+		}
+
+		newPlan := buildPlan(wakeTime, targetWakeTime, adjustment, *sleepNeed, startDate)
+		if err := replacePlan(newPlan); err != nil {
+			return nil, err
+		}
+		displayPlan(a.stdout, newPlan)
+		a.dismissStaleAlarms(func(c CreatedAlarm) bool { return c.Plan != newPlan.id() })
+		return newPlan, nil
+	}
+}
+
+// buildPlan creates a plan from its parameters.
+func buildPlan(wakeTime, targetWakeTime time.Time, adjustment, sleepNeed time.Duration, startDate time.Time) *Plan {
+	return &Plan{
+		ID:              timeNow().Format("20060102T150405.000000"),
+		InitialWakeTime: wakeTime,
+		TargetWakeTime:  targetWakeTime,
+		Adjustment:      adjustment,
+		SleepNeed:       sleepNeed,
+		Schedule:        generateSchedule(wakeTime, targetWakeTime, adjustment, startDate),
+		StartDate:       startDate,
+	}
+}
+
+// replacePlan saves p as the active plan, moving the plan it replaces, if
+// any, to the history.
+func replacePlan(p *Plan) error {
+	archived := ""
+	if existingPlan, err := loadPlan(); err == nil {
+		if err := ensureRevisionBaseline(existingPlan); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+		if archived, err = archivePlan(existingPlan); err != nil {
+			return tr.Errorf("error archiving existing plan: %w", err)
+		}
+	}
+	if err := savePlan(p); err != nil {
+		return tr.Errorf("error saving new plan: %w", err)
+	}
+	record := func() error { return recordRevision(revisionCreate, p) }
+	if archived != "" {
+		record = func() error { return recordOverride(p, archived) }
+	}
+	if err := record(); err != nil {
+		return tr.Errorf("error recording plan revision: %w", err)
+	}
+	return nil
+}
+
+// checkSleepNeed rejects sleep needs below the minimum functional sleep or
+// too long to leave a day.
+func checkSleepNeed(d time.Duration) error {
+	if d < minSleepDuration || d > 16*time.Hour {
+		return tr.Errorf("sleep need %s is outside %s to 16h", d, minSleepDuration)
+	}
+	return nil
+}
+
+func planNewCommand(flags *pflag.FlagSet) runFunc {
+	newPlan := planFlags(flags)
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return tr.Errorf("plan new needs exactly one wake up time (HH:MM)")
+		}
+		_, err := newPlan(a, args[0])
+		return err
+	}
+}
+
+func planShowCommand(flags *pflag.FlagSet) runFunc {
+	output := flags.StringP("output", "o", "text", "Output format (text, calendar, timeline, json, yaml, csv or tsv)")
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		if *output == "calendar" || *output == "timeline" {
+			newTermView(a, p).writeView(a.stdout, *output, p)
+			return nil
+		}
+		return writePlan(a.stdout, *output, p)
+	}
+}
+
+// writePlan prints p as text or in a machine-readable format.
+func writePlan(w io.Writer, format string, p *Plan) error {
+	return writeOutput(w, format, planOutput(p), func(w io.Writer) { displayPlan(w, p) })
+}
+
+func alarmsSyncCommand(flags *pflag.FlagSet) runFunc {
+	alarms := newAlarmFlags(flags)
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		if computeStatus(p, timeNow()).State == stateFinished {
+			tr.Fprintln(a.stdout, "The plan has finished, so its alarms are no longer needed.")
+			return a.dismissAlarms(a.stdout, func(c CreatedAlarm) bool { return c.Plan == p.id() })
+		}
+		return alarms.sync(a, p)
+	}
+}
+
+func reportHTMLCommand(flags *pflag.FlagSet) runFunc {
+	noOpen := flags.Bool("no-open", false, "Do not open the report after generating it")
+	configurable(flags, "no-open")
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		return generateHTML(a.stdout, p, !*noOpen)
+	}
+}
+
+// archivedPlan is a plan that was replaced and moved to the history
+// directory as plan-<number>.json.
+type archivedPlan struct {
+	number   int
+	path     string
+	archived time.Time
+}
+
+// describe summarises the archived plan p on one line.
+func (archived archivedPlan) describe(p *Plan) string {
+	return tr.Sprintf("#%d %s: %s to %s over %d days (replaced %s)",
+		archived.number, tr.date(p.StartDate),
+		tr.clock(p.InitialWakeTime), tr.clock(p.TargetWakeTime), len(p.Days()),
+		tr.day(archived.archived)+" "+tr.clock(archived.archived))
+}
+
+func listArchivedPlans() ([]archivedPlan, error) {
+	entries, err := os.ReadDir(historyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var plans []archivedPlan
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "plan-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "plan-"), ".json"))
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, archivedPlan{number: number, path: filepath.Join(historyPath, name), archived: info.ModTime()})
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].number < plans[j].number })
+	return plans, nil
+}
+
+// ArchivedPlanOutput is the machine-readable form of a replaced plan in
+// the history listing.
+type ArchivedPlanOutput struct {
+	Number      int    `json:"number"`
+	StartDate   string `json:"start_date"`
+	InitialWake string `json:"initial_wake"`
+	TargetWake  string `json:"target_wake"`
+	Days        int    `json:"days"`
+	Replaced    string `json:"replaced"`
+}
+
+func historyCommand(flags *pflag.FlagSet) runFunc {
+	output := outputFlag(flags)
+	return func(a *app, args []string) error {
+		plans, err := listArchivedPlans()
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			number, err := strconv.Atoi(args[0])
+			if err != nil {
+				return tr.Errorf("invalid plan number %q", args[0])
+			}
+			for _, archived := range plans {
+				if archived.number == number {
+					p, err := loadPlanFile(archived.path)
+					if err != nil {
+						return err
+					}
+					return writePlan(a.stdout, *output, p)
+				}
+			}
+			return tr.Errorf("no archived plan #%d", number)
+		}
+
+		list := []ArchivedPlanOutput{}
+		var lines []string
+		for _, archived := range plans {
+			p, err := loadPlanFile(archived.path)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, archived.describe(p))
+			list = append(list, ArchivedPlanOutput{
+				Number:      archived.number,
+				StartDate:   p.StartDate.Format(dateFormat),
+				InitialWake: p.InitialWakeTime.Format(timeFormat),
+				TargetWake:  p.TargetWakeTime.Format(timeFormat),
+				Days:        len(p.Days()),
+				Replaced:    archived.archived.Format(time.RFC3339),
+			})
+		}
+		return writeOutput(a.stdout, *output, list, func(w io.Writer) {
+			if len(plans) == 0 {
+				tr.Fprintln(w, "No replaced plans yet.")
+			}
+			for _, line := range lines {
+				fmt.Fprintln(w, line)
+			}
+		})
+	}
+}
+
+func configPathCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		tr.Fprintf(a.stdout, "Configuration directory: %s\n", configDir)
+		tr.Fprintf(a.stdout, "Config file:             %s\n", configFilePath)
+		tr.Fprintf(a.stdout, "Active plan:             %s\n", configPath)
+		tr.Fprintf(a.stdout, "Replaced plans:          %s\n", historyPath)
+		tr.Fprintf(a.stdout, "Revision log:            %s\n", revisionsPath)
+		tr.Fprintf(a.stdout, "Sleep diary:             %s\n", diaryPath)
+		tr.Fprintf(a.stdout, "Alarms set by eepy:      %s\n", alarmsPath)
+		tr.Fprintf(a.stdout, "Settings before bedtime: %s\n", bedtimeModePath)
+		return nil
+	}
+}
@@ -0,0 +1,351 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Config is the config file: setting values keyed by setting name.
+type Config map[string]string
+
+// setting is a key the config file may set. A setting named like a flag
+// provides the default of that flag in the commands that mark it
+// configurable. Each setting can also be given as an EEPY_ environment
+// variable, which wins over the config file.
+type setting struct {
+	key   string
+	usage string
+	check func(string) error
+}
+
+var settings = []setting{
+	{"target", "Target wake up time for new plans", checkClock},
+	{"adjustment", "Adjustment per day for new plans", checkDuration},
+	{"sleep-need", "How long you want to sleep each night in new plans", func(s string) error {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		return checkSleepNeed(d)
+	}},
+	{"adb", "Set alarms when creating a plan with the older command line", checkBool},
+	{"alarm-backend", "Where alarms are set: adb or termux (default: termux in Termux, adb elsewhere)", checkAlarmBackend},
+	{"device", "Devices to set alarms on, as serials or aliases separated by commas, or all (default: the only one connected)", checkDevices},
+	{"device-aliases", "Names for devices, like phone=R58M123,tablet=emulator-5554", func(s string) error {
+		_, err := parseAliases(s)
+		return err
+	}},
+	{"no-skip-today", "Also set an alarm for today when setting alarms", checkBool},
+	{"alarm-days", "How many days ahead alarms are kept set, up to 7", checkAlarmDays},
+	{"alarm-label", "Template of alarm labels, with {day}, {time}, {number}, {days} and {target} (default: Sleep Adjustment Wake Up: {day})", checkAlarmLabel},
+	{"pre-alarm", "Also set an alarm this long before each wake up alarm", checkAlarmOffset},
+	{"backup-alarm", "Also set an alarm this long after each wake up alarm", checkAlarmOffset},
+	{"one-shot", "Set alarms that ring once instead of every week on their weekday", checkBool},
+	{"vibrate", "Vibrate when an alarm rings", checkBool},
+	{"ringtone", "Ringtone URI of alarms, or silent (default: the clock app's)", checkRingtone},
+	{"skip-ui", "Set alarms without showing the clock app", checkBool},
+	{"bedtime-reminder", "Also remind of each bedtime with a timer when setting alarms", checkBool},
+	{"wind-down", "Also remind this long before each bedtime, like 1h,30m", checkWindDown},
+	{"notify", "Post a notification on the device about the alarms set, if the backend can", checkBool},
+	{"dnd", "Do Not Disturb mode in bedtime mode: priority, alarms, none or off", checkDND},
+	{"grayscale", "Turn the screen gray in bedtime mode", checkBool},
+	{"no-open", "Generate HTML reports without opening them", checkBool},
+	{"tolerance", "How far from the plan a night may be and still count as on plan in stats", checkDuration},
+	{"warn", "How long before bedtime the status bar turns to the soon class", checkDuration},
+	{"locale", "Language and region of the output, like da_DK (default: from LC_ALL, LC_TIME or LANG)", checkLocale},
+	{"clock", "12h or 24h (default: 24h, or the region's clock of the locale setting)", func(s string) error { return (&locale{}).setClock(s) }},
+	{"week-start", "First day of the week, mon or sun (default: from the locale)", func(s string) error { return (&locale{}).setWeekStart(s) }},
+}
+
+func checkClock(s string) error {
+	_, err := parseClock(s)
+	return err
+}
+
+func checkDuration(s string) error {
+	_, err := time.ParseDuration(s)
+	return err
+}
+
+func checkBool(s string) error {
+	_, err := strconv.ParseBool(s)
+	return err
+}
+
+func findSetting(key string) (setting, error) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, nil
+		}
+	}
+	return setting{}, tr.Errorf("unknown setting %q; run 'eepy config list' to see them", key)
+}
+
+// envName is the environment variable for a setting, like EEPY_SLEEP_NEED.
+func envName(key string) string {
+	return "EEPY_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// Where a setting's value comes from.
+const (
+	sourceEnv     = "env"
+	sourceConfig  = "config"
+	sourceBuiltin = "built-in"
+)
+
+// lookupSetting returns the value of a setting from the environment, the
+// config file or the built-in default, in that order, and where it came from.
+func lookupSetting(key string, getenv func(string) string, cfg Config) (string, string) {
+	if getenv != nil {
+		if v := getenv(envName(key)); v != "" {
+			return v, sourceEnv
+		}
+	}
+	if v, ok := cfg[key]; ok {
+		return v, sourceConfig
+	}
+	return builtins[key], sourceBuiltin
+}
+
+func loadConfig() (Config, error) {
+	data, err := os.ReadFile(configFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := Config{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, tr.Errorf("error parsing %s: %w", configFilePath, err)
+	}
+	return cfg, nil
+}
+
+// loadConfigOrBuiltins loads the config for the config commands. A config
+// that cannot be loaded is taken as empty, with a warning, so that these
+// commands can still show the built-in settings and write a working config.
+func (a *app) loadConfigOrBuiltins() Config {
+	cfg, err := loadConfig()
+	if err != nil {
+		tr.Fprintf(a.stderr, "Warning: error loading config: %v; using the built-in settings\n", err)
+		return Config{}
+	}
+	return cfg
+}
+
+// saveConfig writes cfg to a temporary file next to the config file and
+// renames it into place, so a failed write never leaves a broken config.
+func saveConfig(cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configFilePath), 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(configFilePath), ".config-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), configFilePath)
+}
+
+// builtins are the built-in values of the settings that configure flags:
+// the defaults of those flags, which init collects from every command.
+var builtins = map[string]string{}
+
+func init() {
+	setups := []func(*pflag.FlagSet) runFunc{legacyCommand}
+	var walk func(c *command)
+	walk = func(c *command) {
+		if c.setup != nil {
+			setups = append(setups, c.setup)
+		}
+		for _, sub := range c.subcommands {
+			walk(sub)
+		}
+	}
+	walk(rootCommand)
+	for _, setup := range setups {
+		flags := pflag.NewFlagSet("", pflag.ContinueOnError)
+		setup(flags)
+		flags.VisitAll(func(f *pflag.Flag) {
+			if f.Annotations[configAnnotation] == nil {
+				return
+			}
+			value := f.DefValue
+			if _, ok := f.Value.(pflag.SliceValue); ok {
+				value = strings.Trim(value, "[]")
+			}
+			if previous, ok := builtins[f.Name]; ok && previous != value {
+				panic(fmt.Sprintf("flag --%s defaults to both %q and %q", f.Name, previous, value))
+			}
+			builtins[f.Name] = value
+		})
+	}
+}
+
+// configAnnotation marks flags whose default comes from the settings.
+const configAnnotation = "eepy-configurable"
+
+// configurable lets the settings of the same name provide the defaults of
+// the named flags.
+func configurable(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		if err := flags.SetAnnotation(name, configAnnotation, []string{"true"}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// applySettings sets the configurable flags that were not given on the
+// command line from the environment or the config file. Commands without
+// such flags do not read the config, so that a broken one does not stop
+// them.
+func (a *app) applySettings(flags *pflag.FlagSet) error {
+	configured := false
+	flags.VisitAll(func(f *pflag.Flag) {
+		configured = configured || (!f.Changed && f.Annotations[configAnnotation] != nil)
+	})
+	if !configured {
+		return nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return tr.Errorf("error loading config: %w", err)
+	}
+	var errs []error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Changed || f.Annotations[configAnnotation] == nil {
+			return
+		}
+		value, source := lookupSetting(f.Name, a.getenv, cfg)
+		if source == sourceBuiltin {
+			return
+		}
+		if err := f.Value.Set(value); err != nil {
+			name := f.Name
+			if source == sourceEnv {
+				name = envName(f.Name)
+			}
+			errs = append(errs, tr.Errorf("invalid %s %q from %s: %w", name, value, source, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// SettingOutput is a setting as listed by `config list`.
+type SettingOutput struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Usage  string `json:"usage"`
+}
+
+func configListCommand(flags *pflag.FlagSet) runFunc {
+	output := outputFlag(flags)
+	return func(a *app, args []string) error {
+		cfg := a.loadConfigOrBuiltins()
+		var list []SettingOutput
+		for _, s := range settings {
+			value, source := lookupSetting(s.key, a.getenv, cfg)
+			list = append(list, SettingOutput{Key: s.key, Value: value, Source: source, Usage: s.usage})
+		}
+		return writeOutput(a.stdout, *output, list, func(w io.Writer) {
+			width := 0
+			for _, s := range list {
+				width = max(width, len(s.Key))
+			}
+			for _, s := range list {
+				fmt.Fprintf(w, "%-*s %-8s (%s) %s\n", width, s.Key, s.Value, s.Source, tr.T(s.Usage))
+			}
+		})
+	}
+}
+
+func configGetCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return tr.Errorf("config get needs exactly one setting")
+		}
+		if _, err := findSetting(args[0]); err != nil {
+			return err
+		}
+		cfg := a.loadConfigOrBuiltins()
+		value, _ := lookupSetting(args[0], a.getenv, cfg)
+		fmt.Fprintln(a.stdout, value)
+		return nil
+	}
+}
+
+func configSetCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if len(args) != 2 {
+			return tr.Errorf("config set needs a setting and a value")
+		}
+		key, value := args[0], args[1]
+		s, err := findSetting(key)
+		if err != nil {
+			return err
+		}
+		if err := s.check(value); err != nil {
+			return tr.Errorf("invalid value for %s: %w", key, err)
+		}
+		cfg := a.loadConfigOrBuiltins()
+		cfg[key] = value
+		if err := saveConfig(cfg); err != nil {
+			return tr.Errorf("error saving config: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Set %s to %s.\n", key, value)
+		if a.getenv != nil && a.getenv(envName(key)) != "" {
+			tr.Fprintf(a.stderr, "Note: %s is set and takes precedence.\n", envName(key))
+		}
+		return nil
+	}
+}
+
+func configUnsetCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return tr.Errorf("config unset needs exactly one setting")
+		}
+		if _, err := findSetting(args[0]); err != nil {
+			return err
+		}
+		cfg := a.loadConfigOrBuiltins()
+		delete(cfg, args[0])
+		if err := saveConfig(cfg); err != nil {
+			return tr.Errorf("error saving config: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Unset %s.\n", args[0])
+		return nil
+	}
+}
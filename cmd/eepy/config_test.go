@@ -0,0 +1,174 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestApplySettingsPrecedence(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if err := saveConfig(Config{"adjustment": "45m", "sleep-need": "8h"}); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"EEPY_SLEEP_NEED": "7h30m"}
+	a := &app{getenv: func(key string) string { return env[key] }}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	target := flags.String("target", "05:00", "")
+	adjustment := flags.Duration("adjustment", 90*time.Minute, "")
+	sleepNeed := flags.Duration("sleep-need", 9*time.Hour, "")
+	other := flags.Duration("tolerance", 30*time.Minute, "")
+	configurable(flags, "target", "adjustment", "sleep-need")
+	if err := flags.Parse([]string{"--target", "06:00"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.applySettings(flags); err != nil {
+		t.Fatal(err)
+	}
+
+	if *target != "06:00" {
+		t.Errorf("Expected the flag to win, got %s", *target)
+	}
+	if *adjustment != 45*time.Minute {
+		t.Errorf("Expected the config file to set adjustment, got %s", *adjustment)
+	}
+	if *sleepNeed != 7*time.Hour+30*time.Minute {
+		t.Errorf("Expected the environment to win over the config file, got %s", *sleepNeed)
+	}
+	if *other != 30*time.Minute {
+		t.Errorf("Expected flags not marked configurable to keep their default, got %s", *other)
+	}
+
+	env["EEPY_ADJUSTMENT"] = "soon"
+	flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Duration("adjustment", 90*time.Minute, "")
+	configurable(flags, "adjustment")
+	if err := a.applySettings(flags); err == nil || !strings.Contains(err.Error(), "EEPY_ADJUSTMENT") {
+		t.Errorf("Expected an invalid environment value to name the variable, got %v", err)
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	dir := t.TempDir()
+	setConfigDir(dir)
+
+	code, stdout, stderr := runTest(t, "", "config", "set", "target", "06:30")
+	if code != 0 || !strings.Contains(stdout, "Set target to 06:30.") {
+		t.Fatalf("Expected config set to succeed, got %d: %s%s", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err != nil {
+		t.Errorf("Expected config.json to be written: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left behind, got %d files", len(entries))
+	}
+
+	if code, stdout, _ := runTest(t, "", "config", "get", "target"); code != 0 || stdout != "06:30\n" {
+		t.Errorf("Expected config get to print the value, got %d: %q", code, stdout)
+	}
+	if _, stdout, _ := runTest(t, "", "config", "list"); !strings.Contains(stdout, "(config)") || !strings.Contains(stdout, "(built-in)") {
+		t.Errorf("Expected config list to show where values come from, got %s", stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "plan", "new", "10:00", "--adjustment", "1h", "--start-date", "2025-01-01")
+	if code != 0 || !strings.Contains(stdout, "Wake up at 06:30") || strings.Contains(stdout, "Wake up at 05:00") {
+		t.Errorf("Expected new plans to use the configured target, got %d: %s", code, stdout)
+	}
+
+	if code, _, stderr := runTest(t, "", "config", "set", "target", "late"); code != 1 || !strings.Contains(stderr, "invalid value for target") {
+		t.Errorf("Expected an invalid value to be rejected, got %d: %s", code, stderr)
+	}
+	if code, _, stderr := runTest(t, "", "config", "get", "colour"); code != 1 || !strings.Contains(stderr, `unknown setting "colour"`) {
+		t.Errorf("Expected an unknown setting to be rejected, got %d: %s", code, stderr)
+	}
+
+	for _, args := range [][]string{{"locale", "xx_YY"}, {"device", "phone=R58M123"}, {"device", "my phone"}, {"ringtone", "beep"}, {"device-aliases", "phone=R58 M123"}} {
+		if code, _, stderr := runTest(t, "", append([]string{"config", "set"}, args...)...); code != 1 || !strings.Contains(stderr, "invalid value for "+args[0]) {
+			t.Errorf("%q: expected the value to be rejected, got %d: %s", args, code, stderr)
+		}
+	}
+	for _, args := range [][]string{{"locale", "de_DE.UTF-8"}, {"device", "phone,emulator-5554,192.168.1.5:5555"}, {"ringtone", "silent"}} {
+		if code, _, stderr := runTest(t, "", append([]string{"config", "set"}, args...)...); code != 0 {
+			t.Errorf("%q: expected the value to be accepted, got %d: %s", args, code, stderr)
+		}
+	}
+
+	runTest(t, "", "config", "unset", "target")
+	if _, stdout, _ := runTest(t, "", "config", "get", "target"); stdout != "05:00\n" {
+		t.Errorf("Expected unset to restore the built-in default, got %q", stdout)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if err := os.WriteFile(configFilePath, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(); err == nil {
+		t.Error("Expected a broken config file to be an error")
+	}
+	a := &app{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	if err := a.applySettings(flags); err != nil {
+		t.Errorf("Expected flags without settings to ignore the config, got %v", err)
+	}
+	flags.String("target", "05:00", "")
+	configurable(flags, "target")
+	if err := a.applySettings(flags); err == nil || !strings.Contains(err.Error(), "error loading config") {
+		t.Errorf("Expected applySettings to report the broken config, got %v", err)
+	}
+}
+
+func TestRepairBrokenConfig(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if err := os.WriteFile(configFilePath, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useLocale(t, *tr)
+	var stderr bytes.Buffer
+	setLocale(func(string) string { return "" }, &stderr)
+	if stderr.Len() != 0 {
+		t.Errorf("Expected the broken config to be left to the commands, got %q", stderr.String())
+	}
+
+	if code, stdout, stderr := runTest(t, "", "config", "get", "target"); code != 0 || stdout != "05:00\n" || !strings.Contains(stderr, "using the built-in settings") {
+		t.Errorf("Expected config get to fall back to the built-in setting, got %d, %q: %s", code, stdout, stderr)
+	}
+	if code, _, stderr := runTest(t, "", "config", "set", "target", "06:00"); code != 0 || !strings.Contains(stderr, "Warning: error loading config") {
+		t.Errorf("Expected config set to replace the broken config, got %d: %s", code, stderr)
+	}
+	if code, stdout, stderr := runTest(t, "", "config", "get", "target"); code != 0 || stdout != "06:00\n" || stderr != "" {
+		t.Errorf("Expected the repaired config to be read, got %d, %q: %s", code, stdout, stderr)
+	}
+
+	setLocale(func(key string) string { return map[string]string{"EEPY_CLOCK": "13h", "EEPY_WEEK_START": "sun"}[key] }, &stderr)
+	if !strings.Contains(stderr.String(), "Warning:") || tr.weekStart != time.Sunday {
+		t.Errorf("Expected an invalid clock to be warned about and the other settings kept, got %q", stderr.String())
+	}
+}
+
+func TestBuiltinsFromFlags(t *testing.T) {
+	for key := range builtins {
+		if _, err := findSetting(key); err != nil {
+			t.Errorf("Expected the configurable flag --%s to have a setting", key)
+		}
+	}
+	tests := map[string]string{"target": "05:00", "sleep-need": "9h0m0s", "alarm-days": "7", "device": "", "wind-down": "", "dnd": "priority", "adb": "false"}
+	for key, want := range tests {
+		if got, source := lookupSetting(key, nil, nil); got != want || source != sourceBuiltin {
+			t.Errorf("%s: expected the flag default %q, got %q from %s", key, want, got, source)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// timeNow is the clock used for anything relative to today. Tests replace it.
+var timeNow = time.Now
+
+// OverrideKind is the kind of change an Override makes to a plan day.
+type OverrideKind string
+
+const (
+	overrideWake    OverrideKind = "wake"
+	overrideBedtime OverrideKind = "bedtime"
+	overrideNote    OverrideKind = "note"
+	overrideInsert  OverrideKind = "insert"
+	overrideRemove  OverrideKind = "remove"
+)
+
+// Override is a user edit to a single day of a plan. Overrides are kept apart
+// from the generated Schedule so that regenerating the plan keeps them. Date
+// is where the day was when the edit was made; later inserts, removals and
+// pauses take the edit along with the day.
+type Override struct {
+	Kind    OverrideKind
+	Date    time.Time
+	Time    time.Time
+	Note    string `json:",omitempty"`
+	Created time.Time
+}
+
+// Day is one day of a plan after overrides have been applied.
+type Day struct {
+	Number  int
+	Date    time.Time
+	Wake    time.Time
+	Bedtime time.Time
+	Note    string
+
+	Inserted          bool
+	WakeOverridden    bool
+	BedtimeOverridden bool
+
+	// bedtimeClock is the time of day of a bedtime override, and overrides
+	// are the indexes of the wake, bedtime and note overrides of the day.
+	bedtimeClock time.Time
+	overrides    []int
+}
+
+// Overridden reports whether anything about the day differs from the
+// generated schedule.
+func (d Day) Overridden() bool {
+	return d.Inserted || d.WakeOverridden || d.BedtimeOverridden || d.Note != ""
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// atClock returns the time on date with the hour and minute of clock.
+func atClock(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, date.Location())
+}
+
+// bedtimeBefore returns the last time with the hour and minute of clock that
+// is before wake.
+func bedtimeBefore(wake, clock time.Time) time.Time {
+	bedtime := atClock(wake, clock)
+	if !bedtime.Before(wake) {
+		bedtime = bedtime.AddDate(0, 0, -1)
+	}
+	return bedtime
+}
+
+// clockOf strips the date from t so wall clock times can be compared.
+func clockOf(t time.Time) time.Time {
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+// layoutChange is a change that moves days of a plan to other dates.
+type layoutChange struct {
+	created time.Time
+	apply   func([]Day) []Day
+}
+
+// Days lays out the plan day by day: the generated schedule with inserted and
+// removed days, pauses and wake time, bedtime and note overrides applied in
+// the order they were made.
+func (p *Plan) Days() []Day {
+	days := make([]Day, len(p.Schedule))
+	for i, wakeTime := range p.Schedule {
+		days[i] = Day{
+			Date: dateOf(p.StartDate.AddDate(0, 0, i)),
+			Wake: wakeTime,
+		}
+	}
+
+	var changes []layoutChange
+	for i, o := range p.Overrides {
+		switch o.Kind {
+		case overrideInsert:
+			changes = append(changes, layoutChange{o.Created, func(days []Day) []Day { return insertDay(days, o.Date) }})
+		case overrideRemove:
+			changes = append(changes, layoutChange{o.Created, func(days []Day) []Day { return removeDay(days, o.Date) }})
+		default:
+			changes = append(changes, layoutChange{o.Created, func(days []Day) []Day { return overrideDay(days, i, o) }})
+		}
+	}
+	for _, pause := range p.Pauses {
+		changes = append(changes, layoutChange{pause.Created, func(days []Day) []Day {
+			return shiftDays(days, pause.Start, pause.length())
+		}})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].created.Before(changes[j].created) })
+	for _, change := range changes {
+		days = change.apply(days)
+	}
+
+	for i := range days {
+		d := &days[i]
+		d.Number = i + 1
+		d.Wake = atClock(d.Date, d.Wake)
+		if d.BedtimeOverridden {
+			d.Bedtime = bedtimeBefore(d.Wake, d.bedtimeClock)
+		} else {
+			d.Bedtime = d.Wake.Add(-p.sleepNeed())
+		}
+	}
+	return days
+}
+
+// overrideDay applies override i, o, to the day on o.Date.
+func overrideDay(days []Day, i int, o Override) []Day {
+	j := dayIndex(days, o.Date)
+	if j < 0 {
+		return days
+	}
+	d := &days[j]
+	switch o.Kind {
+	case overrideWake:
+		d.Wake = o.Time
+		d.WakeOverridden = true
+	case overrideBedtime:
+		d.bedtimeClock = o.Time
+		d.BedtimeOverridden = true
+	case overrideNote:
+		d.Note = o.Note
+	}
+	d.overrides = append(d.overrides, i)
+	return days
+}
+
+func dayIndex(days []Day, date time.Time) int {
+	for i, d := range days {
+		if sameDate(d.Date, date) {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertDay adds a day on date that repeats the wake time planned for that
+// date, pushing it and every later day back by one.
+func insertDay(days []Day, date time.Time) []Day {
+	i := dayIndex(days, date)
+	if i < 0 {
+		return days
+	}
+	inserted := days[i]
+	inserted.Inserted = true
+	inserted.Note = ""
+	inserted.overrides = nil
+	days = append(days, Day{})
+	copy(days[i+1:], days[i:])
+	days[i] = inserted
+	for j := i + 1; j < len(days); j++ {
+		days[j].Date = days[j].Date.AddDate(0, 0, 1)
+	}
+	return days
+}
+
+// removeDay drops the day on date, pulling every later day forward by one.
+func removeDay(days []Day, date time.Time) []Day {
+	i := dayIndex(days, date)
+	if i < 0 || len(days) == 1 {
+		return days
+	}
+	days = append(days[:i], days[i+1:]...)
+	for j := i; j < len(days); j++ {
+		days[j].Date = days[j].Date.AddDate(0, 0, -1)
+	}
+	return days
+}
+
+// shiftDays moves every day on or after date n days later.
+func shiftDays(days []Day, date time.Time, n int) []Day {
+	from := dateOf(date)
+	for i := range days {
+		if !days[i].Date.Before(from) {
+			days[i].Date = days[i].Date.AddDate(0, 0, n)
+		}
+	}
+	return days
+}
+
+// ReachesTarget reports whether waking at wakeTime meets the plan's target.
+func (p *Plan) ReachesTarget(wakeTime time.Time) bool {
+	return !clockOf(wakeTime).After(clockOf(p.TargetWakeTime))
+}
@@ -0,0 +1,141 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func testPlan(t *testing.T) *Plan {
+	t.Helper()
+	wakeTime, _ := time.Parse(timeFormat, "10:00")
+	targetWakeTime, _ := time.Parse(timeFormat, "08:00")
+	startDate, _ := time.Parse(dateFormat, "2025-07-13")
+	return &Plan{
+		InitialWakeTime: wakeTime,
+		TargetWakeTime:  targetWakeTime,
+		Adjustment:      time.Hour,
+		Schedule:        generateSchedule(wakeTime, targetWakeTime, time.Hour, startDate),
+		StartDate:       startDate,
+	}
+}
+
+func testDate(s string) time.Time {
+	date, _ := time.Parse(dateFormat, s)
+	return date
+}
+
+func testClock(s string) time.Time {
+	clock, _ := time.Parse(timeFormat, s)
+	return clock
+}
+
+func TestDaysWithoutOverrides(t *testing.T) {
+	days := testPlan(t).Days()
+
+	if len(days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(days))
+	}
+	if got := days[1].Wake.Format("2006-01-02 15:04"); got != "2025-07-14 09:00" {
+		t.Errorf("Expected day 2 to wake at 2025-07-14 09:00, got %s", got)
+	}
+	if got := days[1].Bedtime.Format("2006-01-02 15:04"); got != "2025-07-14 00:00" {
+		t.Errorf("Expected day 2 bedtime at 2025-07-14 00:00, got %s", got)
+	}
+}
+
+func TestDaysWithTimeOverrides(t *testing.T) {
+	p := testPlan(t)
+	p.Overrides = []Override{
+		{Kind: overrideWake, Date: testDate("2025-07-14"), Time: testClock("07:30")},
+		{Kind: overrideBedtime, Date: testDate("2025-07-15"), Time: testClock("22:15")},
+		{Kind: overrideNote, Date: testDate("2025-07-15"), Note: "Early flight"},
+	}
+	days := p.Days()
+
+	if !days[1].WakeOverridden || days[1].Wake.Format(timeFormat) != "07:30" {
+		t.Errorf("Expected day 2 wake override to 07:30, got %s", days[1].Wake.Format(timeFormat))
+	}
+	if days[1].Bedtime.Format("2006-01-02 15:04") != "2025-07-13 22:30" {
+		t.Errorf("Expected day 2 bedtime to follow the wake override, got %s", days[1].Bedtime.Format("2006-01-02 15:04"))
+	}
+	if !days[2].BedtimeOverridden || days[2].Bedtime.Format("2006-01-02 15:04") != "2025-07-14 22:15" {
+		t.Errorf("Expected day 3 bedtime override the evening before, got %s", days[2].Bedtime.Format("2006-01-02 15:04"))
+	}
+	if days[2].Note != "Early flight" || days[0].Overridden() {
+		t.Errorf("Expected only day 3 to carry the note, got %q and %v", days[2].Note, days[0].Overridden())
+	}
+}
+
+func TestDaysWithInsertAndRemove(t *testing.T) {
+	p := testPlan(t)
+	p.Overrides = []Override{
+		{Kind: overrideInsert, Date: testDate("2025-07-14")},
+		{Kind: overrideRemove, Date: testDate("2025-07-13")},
+	}
+	days := p.Days()
+
+	if len(days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(days))
+	}
+	want := []string{"2025-07-13 09:00", "2025-07-14 09:00", "2025-07-15 08:00"}
+	for i, d := range days {
+		if got := d.Wake.Format("2006-01-02 15:04"); got != want[i] {
+			t.Errorf("Expected day %d to wake at %s, got %s", i+1, want[i], got)
+		}
+	}
+	if !days[0].Inserted || days[1].Inserted {
+		t.Errorf("Expected only the first day to be inserted")
+	}
+}
+
+func TestOverridesMoveWithTheirDay(t *testing.T) {
+	p := testPlan(t)
+	created := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	p.Overrides = []Override{
+		{Kind: overrideWake, Date: testDate("2025-07-14"), Time: testClock("07:30"), Created: created},
+		{Kind: overrideNote, Date: testDate("2025-07-14"), Note: "Dentist", Created: created},
+		{Kind: overrideInsert, Date: testDate("2025-07-13"), Created: created.Add(time.Hour)},
+	}
+	days := p.Days()
+
+	if len(days) != 4 {
+		t.Fatalf("Expected 4 days, got %d", len(days))
+	}
+	if days[1].Overridden() || days[1].Wake.Format(timeFormat) != "10:00" {
+		t.Errorf("Expected the day pushed onto Jul 14 to keep its own wake up time, got %s", days[1].Wake.Format(timeFormat))
+	}
+	if got := days[2].Wake.Format("2006-01-02 15:04"); got != "2025-07-15 07:30" || days[2].Note != "Dentist" {
+		t.Errorf("Expected the edited day to move to Jul 15 with its edits, got %s %q", got, days[2].Note)
+	}
+	if len(days[2].overrides) != 2 {
+		t.Errorf("Expected the edited day to know its 2 overrides, got %v", days[2].overrides)
+	}
+
+	// A wake time override made after the insert applies to the day that
+	// is on the date by then.
+	p.Overrides = append(p.Overrides, Override{Kind: overrideWake, Date: testDate("2025-07-14"), Time: testClock("06:00"), Created: created.Add(2 * time.Hour)})
+	if days := p.Days(); days[1].Wake.Format(timeFormat) != "06:00" || days[2].Wake.Format(timeFormat) != "07:30" {
+		t.Errorf("Expected the new override on Jul 14 only, got %s and %s", days[1].Wake.Format(timeFormat), days[2].Wake.Format(timeFormat))
+	}
+}
+
+func TestReplanKeepsOverrides(t *testing.T) {
+	p := testPlan(t)
+	p.Overrides = []Override{{Kind: overrideWake, Date: testDate("2025-07-14"), Time: testClock("07:30")}}
+	p.TargetWakeTime = testClock("06:00")
+	p.Schedule = generateSchedule(p.InitialWakeTime, p.TargetWakeTime, p.Adjustment, p.StartDate)
+	days := p.Days()
+
+	if len(days) != 5 {
+		t.Fatalf("Expected 5 days, got %d", len(days))
+	}
+	if days[1].Wake.Format(timeFormat) != "07:30" {
+		t.Errorf("Expected wake override to survive replanning, got %s", days[1].Wake.Format(timeFormat))
+	}
+}
@@ -0,0 +1,502 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Awakening is a period of wakefulness during the night.
+type Awakening struct {
+	Time     time.Time
+	Duration time.Duration
+}
+
+// DiaryEntry describes one night of sleep, ending on the morning of Date.
+// The diary is append-only: a correction is stored as a new line with the ID
+// of the entry it replaces, and the last line for an ID wins.
+type DiaryEntry struct {
+	ID       int
+	Recorded time.Time
+	Deleted  bool `json:",omitempty"`
+
+	Date    time.Time
+	PlanID  string `json:",omitempty"`
+	PlanDay int    `json:",omitempty"`
+
+	Bedtime    time.Time
+	SleepOnset time.Time
+	Awakenings []Awakening `json:",omitempty"`
+	FinalWake  time.Time
+	OutOfBed   time.Time
+	Quality    int    `json:",omitempty"`
+	Note       string `json:",omitempty"`
+}
+
+// AwakeDuration is the time spent awake after falling asleep.
+func (e DiaryEntry) AwakeDuration() time.Duration {
+	var total time.Duration
+	for _, a := range e.Awakenings {
+		total += a.Duration
+	}
+	return total
+}
+
+// SleepDuration is the time asleep between sleep onset and final wake. The
+// bedtime stands in for a missing sleep onset.
+func (e DiaryEntry) SleepDuration() time.Duration {
+	onset := e.SleepOnset
+	if onset.IsZero() {
+		onset = e.Bedtime
+	}
+	if onset.IsZero() || e.FinalWake.IsZero() {
+		return 0
+	}
+	return e.FinalWake.Sub(onset) - e.AwakeDuration()
+}
+
+// nightTime places clock in the night ending on the morning of date: times
+// from noon on are on the evening before, earlier times on date itself.
+func nightTime(date, clock time.Time) time.Time {
+	t := atClock(date.AddDate(0, 0, -1), clock)
+	if clock.Hour() < 12 {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+func (e DiaryEntry) validate() error {
+	order := []struct {
+		name string
+		t    time.Time
+	}{
+		{"bedtime", e.Bedtime},
+		{"sleep onset", e.SleepOnset},
+		{"final wake", e.FinalWake},
+		{"out of bed", e.OutOfBed},
+	}
+	var prev string
+	var prevTime time.Time
+	for _, o := range order {
+		if o.t.IsZero() {
+			continue
+		}
+		if !prevTime.IsZero() && o.t.Before(prevTime) {
+			return tr.Errorf("%s at %s is before %s at %s", o.name, o.t.Format(timeFormat), prev, prevTime.Format(timeFormat))
+		}
+		prev, prevTime = o.name, o.t
+	}
+	if e.Quality < 0 || e.Quality > 5 {
+		return tr.Errorf("quality must be between 1 and 5, or 0 for none, got %d", e.Quality)
+	}
+	if e.FinalWake.IsZero() {
+		return tr.Errorf("a final wake time is needed")
+	}
+	asleep := e.SleepOnset
+	if asleep.IsZero() {
+		asleep = e.Bedtime
+	}
+	for _, a := range e.Awakenings {
+		if a.Duration <= 0 {
+			return tr.Errorf("awakening at %s must last a while, got %s", a.Time.Format(timeFormat), a.Duration)
+		}
+		if a.Time.Before(asleep) || a.Time.Add(a.Duration).After(e.FinalWake) {
+			return tr.Errorf("awakening at %s for %s is not between falling asleep and the final wake", a.Time.Format(timeFormat), a.Duration)
+		}
+	}
+	return nil
+}
+
+func appendDiaryEntry(e DiaryEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(diaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readDiaryLines returns every line of the diary in the order written.
+func readDiaryLines() ([]DiaryEntry, error) {
+	f, err := os.Open(diaryPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []DiaryEntry
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var e DiaryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, tr.Errorf("error reading diary line %d: %w", n, err)
+		}
+		lines = append(lines, e)
+	}
+	return lines, scanner.Err()
+}
+
+// loadDiary returns the current version of every entry that has not been
+// deleted, ordered by date.
+func loadDiary() ([]DiaryEntry, error) {
+	lines, err := readDiaryLines()
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[int]DiaryEntry)
+	for _, e := range lines {
+		latest[e.ID] = e
+	}
+	var entries []DiaryEntry
+	for _, e := range latest {
+		if !e.Deleted {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+func nextDiaryID() (int, error) {
+	lines, err := readDiaryLines()
+	if err != nil {
+		return 0, err
+	}
+	next := 1
+	for _, e := range lines {
+		if e.ID >= next {
+			next = e.ID + 1
+		}
+	}
+	return next, nil
+}
+
+func findDiaryEntry(id int) (DiaryEntry, error) {
+	entries, err := loadDiary()
+	if err != nil {
+		return DiaryEntry{}, err
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return DiaryEntry{}, tr.Errorf("no diary entry #%d", id)
+}
+
+// linkToPlan records which day of the active plan the entry belongs to.
+func (e *DiaryEntry) linkToPlan() {
+	e.PlanID, e.PlanDay = "", 0
+	p, err := loadPlan()
+	if err != nil {
+		return
+	}
+	for _, d := range p.Days() {
+		if sameDate(d.Date, e.Date) {
+			e.PlanID, e.PlanDay = p.id(), d.Number
+			return
+		}
+	}
+}
+
+// diaryFlags are the flags shared by `log add` and `log correct`.
+type diaryFlags struct {
+	bedtime, onset, wake, up *string
+	awake                    *[]string
+	quality                  *int
+	note                     *string
+}
+
+func addDiaryFlags(flags *pflag.FlagSet) diaryFlags {
+	return diaryFlags{
+		bedtime: flags.String("bedtime", "", "When you went to bed (HH:MM)"),
+		onset:   flags.String("onset", "", "When you fell asleep (HH:MM)"),
+		awake:   flags.StringArray("awake", nil, "A night awakening as HH:MM/DURATION, e.g. 03:10/20m (repeatable)"),
+		wake:    flags.String("wake", "", "When you woke up for the last time (HH:MM)"),
+		up:      flags.String("up", "", "When you got out of bed (HH:MM)"),
+		quality: flags.Int("quality", 0, "How well you slept, from 1 (poor) to 5 (great), or 0 for none"),
+		note:    flags.String("note", "", "A note about the night"),
+	}
+}
+
+// apply sets the fields of e for every flag given on the command line.
+func (df diaryFlags) apply(flags *pflag.FlagSet, e *DiaryEntry) error {
+	clocks := []struct {
+		name  string
+		value *string
+		field *time.Time
+	}{
+		{"bedtime", df.bedtime, &e.Bedtime},
+		{"onset", df.onset, &e.SleepOnset},
+		{"wake", df.wake, &e.FinalWake},
+		{"up", df.up, &e.OutOfBed},
+	}
+	for _, c := range clocks {
+		if !flags.Changed(c.name) {
+			continue
+		}
+		if *c.value == "" {
+			*c.field = time.Time{}
+			continue
+		}
+		clock, err := parseClock(*c.value)
+		if err != nil {
+			return tr.Errorf("error parsing %s: %w", c.name, err)
+		}
+		*c.field = nightTime(e.Date, clock)
+	}
+	if flags.Changed("awake") {
+		e.Awakenings = nil
+		for _, s := range *df.awake {
+			clockStr, durationStr, ok := strings.Cut(s, "/")
+			if !ok {
+				return tr.Errorf("error parsing awake %q: expected HH:MM/DURATION", s)
+			}
+			clock, err := parseClock(clockStr)
+			if err != nil {
+				return tr.Errorf("error parsing awake %q: %w", s, err)
+			}
+			duration, err := time.ParseDuration(durationStr)
+			if err != nil {
+				return tr.Errorf("error parsing awake %q: %w", s, err)
+			}
+			e.Awakenings = append(e.Awakenings, Awakening{Time: nightTime(e.Date, clock), Duration: duration})
+		}
+	}
+	if flags.Changed("quality") {
+		e.Quality = *df.quality
+	}
+	if flags.Changed("note") {
+		e.Note = *df.note
+	}
+	return nil
+}
+
+func logAddCommand(flags *pflag.FlagSet) runFunc {
+	dateStr := flags.String("date", "", "The morning the night ended on (YYYY-MM-DD, default: today)")
+	df := addDiaryFlags(flags)
+
+	return func(a *app, args []string) error {
+		date, err := parseDateFlag(*dateStr, time.UTC)
+		if err != nil {
+			return tr.Errorf("error parsing date: %w", err)
+		}
+		id, err := nextDiaryID()
+		if err != nil {
+			return err
+		}
+		e := DiaryEntry{ID: id, Recorded: timeNow(), Date: date}
+		if err := df.apply(flags, &e); err != nil {
+			return err
+		}
+		if err := e.validate(); err != nil {
+			return err
+		}
+		e.linkToPlan()
+		if err := appendDiaryEntry(e); err != nil {
+			return tr.Errorf("error saving diary entry: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Logged entry #%d.\n", e.ID)
+		printDiaryEntry(a.stdout, e)
+		return nil
+	}
+}
+
+func logCorrectCommand(flags *pflag.FlagSet) runFunc {
+	dateStr := flags.String("date", "", "Move the entry to another morning (YYYY-MM-DD)")
+	df := addDiaryFlags(flags)
+
+	return func(a *app, args []string) error {
+		e, err := diaryEntryArg(args)
+		if err != nil {
+			return err
+		}
+		moved := false
+		if *dateStr != "" {
+			date, err := parseDateFlag(*dateStr, time.UTC)
+			if err != nil {
+				return tr.Errorf("error parsing date: %w", err)
+			}
+			moved = !sameDate(date, e.Date)
+			e = e.moveTo(date)
+		}
+		if err := df.apply(flags, &e); err != nil {
+			return err
+		}
+		if err := e.validate(); err != nil {
+			return err
+		}
+		e.Recorded = timeNow()
+		// An entry stays with the plan it was logged under, which may
+		// since have been replaced, unless it moves to another night.
+		if moved {
+			e.linkToPlan()
+		}
+		if err := appendDiaryEntry(e); err != nil {
+			return tr.Errorf("error saving diary entry: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Corrected entry #%d.\n", e.ID)
+		printDiaryEntry(a.stdout, e)
+		return nil
+	}
+}
+
+func logDeleteCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		e, err := diaryEntryArg(args)
+		if err != nil {
+			return err
+		}
+		if err := appendDiaryEntry(DiaryEntry{ID: e.ID, Recorded: timeNow(), Deleted: true, Date: e.Date}); err != nil {
+			return tr.Errorf("error saving diary entry: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Deleted entry #%d.\n", e.ID)
+		return nil
+	}
+}
+
+func diaryEntryArg(args []string) (DiaryEntry, error) {
+	if len(args) != 1 {
+		return DiaryEntry{}, tr.Errorf("expected exactly one entry number")
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return DiaryEntry{}, tr.Errorf("invalid entry number %q", args[0])
+	}
+	return findDiaryEntry(id)
+}
+
+// moveTo returns a copy of the entry with every time moved to the night
+// ending on date.
+func (e DiaryEntry) moveTo(date time.Time) DiaryEntry {
+	days := int(date.Sub(e.Date).Hours()+12) / 24
+	move := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		return t.AddDate(0, 0, days)
+	}
+	e.Date = date
+	e.Bedtime, e.SleepOnset = move(e.Bedtime), move(e.SleepOnset)
+	e.FinalWake, e.OutOfBed = move(e.FinalWake), move(e.OutOfBed)
+	awakenings := make([]Awakening, len(e.Awakenings))
+	for i, a := range e.Awakenings {
+		awakenings[i] = Awakening{Time: move(a.Time), Duration: a.Duration}
+	}
+	e.Awakenings = awakenings
+	return e
+}
+
+func logListCommand(flags *pflag.FlagSet) runFunc {
+	fromStr := flags.String("from", "", "First morning to list (YYYY-MM-DD)")
+	toStr := flags.String("to", "", "Last morning to list (YYYY-MM-DD)")
+
+	return func(a *app, args []string) error {
+		entries, err := loadDiary()
+		if err != nil {
+			return err
+		}
+		entries, err = filterDiary(entries, *fromStr, *toStr)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			tr.Fprintln(a.stdout, "No diary entries found. Record one with 'eepy log add'.")
+			return nil
+		}
+		for _, e := range entries {
+			printDiaryEntry(a.stdout, e)
+		}
+		return nil
+	}
+}
+
+// filterDiary keeps the entries for mornings between from and to, both
+// inclusive. Empty bounds are open.
+func filterDiary(entries []DiaryEntry, fromStr, toStr string) ([]DiaryEntry, error) {
+	var from, to time.Time
+	var err error
+	if fromStr != "" {
+		if from, err = parseDateFlag(fromStr, time.UTC); err != nil {
+			return nil, tr.Errorf("error parsing from: %w", err)
+		}
+	}
+	if toStr != "" {
+		if to, err = parseDateFlag(toStr, time.UTC); err != nil {
+			return nil, tr.Errorf("error parsing to: %w", err)
+		}
+	}
+	var filtered []DiaryEntry
+	for _, e := range entries {
+		if !from.IsZero() && e.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && e.Date.After(to) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered, nil
+}
+
+func printDiaryEntry(w io.Writer, e DiaryEntry) {
+	planDay := ""
+	if e.PlanDay > 0 {
+		planDay = tr.Sprintf(" (Day %d)", e.PlanDay)
+	}
+	fmt.Fprintf(w, "#%d %s%s:\n", e.ID, tr.day(e.Date), planDay)
+	printClock := func(label string, t time.Time) {
+		if !t.IsZero() {
+			fmt.Fprintf(w, "  - %s %s\n", tr.T(label), tr.clock(t))
+		}
+	}
+	printClock("In bed at", e.Bedtime)
+	printClock("Asleep at", e.SleepOnset)
+	for _, a := range e.Awakenings {
+		tr.Fprintf(w, "  - Awake at %s for %s\n", tr.clock(a.Time), a.Duration)
+	}
+	printClock("Woke up at", e.FinalWake)
+	printClock("Out of bed at", e.OutOfBed)
+	if d := e.SleepDuration(); d > 0 {
+		tr.Fprintf(w, "  - Slept %.1f hours\n", d.Hours())
+	}
+	if e.Quality > 0 {
+		tr.Fprintf(w, "  - Quality %d/5\n", e.Quality)
+	}
+	if e.Note != "" {
+		tr.Fprintf(w, "  - Note: %s\n", e.Note)
+	}
+}
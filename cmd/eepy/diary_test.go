@@ -0,0 +1,143 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNightTime(t *testing.T) {
+	date := testDate("2025-07-14")
+
+	if got := nightTime(date, testClock("23:30")).Format("2006-01-02 15:04"); got != "2025-07-13 23:30" {
+		t.Errorf("Expected evening times on the day before, got %s", got)
+	}
+	if got := nightTime(date, testClock("06:45")).Format("2006-01-02 15:04"); got != "2025-07-14 06:45" {
+		t.Errorf("Expected morning times on the date itself, got %s", got)
+	}
+}
+
+func TestSleepDuration(t *testing.T) {
+	date := testDate("2025-07-14")
+	e := DiaryEntry{
+		Date:       date,
+		Bedtime:    nightTime(date, testClock("23:00")),
+		SleepOnset: nightTime(date, testClock("23:30")),
+		Awakenings: []Awakening{{Time: nightTime(date, testClock("03:00")), Duration: 30 * time.Minute}},
+		FinalWake:  nightTime(date, testClock("07:00")),
+	}
+
+	if got := e.SleepDuration(); got != 7*time.Hour {
+		t.Errorf("Expected 7h of sleep, got %s", got)
+	}
+	e.SleepOnset = time.Time{}
+	if got := e.SleepDuration(); got != 7*time.Hour+30*time.Minute {
+		t.Errorf("Expected bedtime to stand in for sleep onset, got %s", got)
+	}
+}
+
+func TestDiaryValidate(t *testing.T) {
+	date := testDate("2025-07-14")
+	e := DiaryEntry{
+		Date:       date,
+		SleepOnset: nightTime(date, testClock("23:30")),
+		FinalWake:  nightTime(date, testClock("07:00")),
+		OutOfBed:   nightTime(date, testClock("06:30")),
+	}
+	if err := e.validate(); err == nil {
+		t.Errorf("Expected getting up before the final wake to be rejected")
+	}
+	e.OutOfBed = time.Time{}
+	if err := e.validate(); err != nil {
+		t.Errorf("Expected entry to be valid, got %v", err)
+	}
+
+	for _, a := range []Awakening{
+		{Time: nightTime(date, testClock("03:00")), Duration: -20 * time.Minute},
+		{Time: nightTime(date, testClock("23:00")), Duration: 20 * time.Minute},
+		{Time: nightTime(date, testClock("06:50")), Duration: 20 * time.Minute},
+	} {
+		e.Awakenings = []Awakening{a}
+		if err := e.validate(); err == nil {
+			t.Errorf("Expected the awakening at %s for %s to be rejected", a.Time.Format(timeFormat), a.Duration)
+		}
+	}
+	e.Awakenings = []Awakening{{Time: nightTime(date, testClock("03:00")), Duration: 20 * time.Minute}}
+	if err := e.validate(); err != nil {
+		t.Errorf("Expected the awakening to be valid, got %v", err)
+	}
+	e.Quality = 6
+	if err := e.validate(); err == nil || !strings.Contains(err.Error(), "or 0 for none") {
+		t.Errorf("Expected the quality to be rejected with the accepted range, got %v", err)
+	}
+}
+
+func TestDiaryCorrectionsAndDeletes(t *testing.T) {
+	setConfigDir(t.TempDir())
+	date := testDate("2025-07-14")
+
+	entries := []DiaryEntry{
+		{ID: 1, Date: date, FinalWake: nightTime(date, testClock("07:00")), Quality: 3},
+		{ID: 2, Date: date.AddDate(0, 0, -1), FinalWake: nightTime(date, testClock("08:00"))},
+		{ID: 1, Date: date, FinalWake: nightTime(date, testClock("06:30")), Quality: 4},
+		{ID: 2, Deleted: true},
+	}
+	for _, e := range entries {
+		if err := appendDiaryEntry(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diary, err := loadDiary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diary) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(diary))
+	}
+	if diary[0].Quality != 4 || diary[0].FinalWake.Format(timeFormat) != "06:30" {
+		t.Errorf("Expected the correction to replace the entry, got %+v", diary[0])
+	}
+	if id, _ := nextDiaryID(); id != 3 {
+		t.Errorf("Expected next id 3, got %d", id)
+	}
+}
+
+func TestDiaryMoveTo(t *testing.T) {
+	date := testDate("2025-07-14")
+	e := DiaryEntry{Date: date, Bedtime: nightTime(date, testClock("23:00")), FinalWake: nightTime(date, testClock("07:00"))}
+	moved := e.moveTo(testDate("2025-07-16"))
+
+	if got := moved.Bedtime.Format("2006-01-02 15:04"); got != "2025-07-15 23:00" {
+		t.Errorf("Expected bedtime to move with the entry, got %s", got)
+	}
+	if !e.Bedtime.Equal(nightTime(date, testClock("23:00"))) {
+		t.Errorf("Expected the original entry to be left alone")
+	}
+}
+
+func TestDiaryCorrectionKeepsPlan(t *testing.T) {
+	setConfigDir(t.TempDir())
+	runTest(t, "", "plan", "new", "10:00", "--target", "08:00", "--adjustment", "1h", "--start-date", "2025-07-13")
+	runTest(t, "", "log", "add", "--date", "2025-07-14", "--bedtime", "23:00", "--wake", "09:00")
+	first, err := findDiaryEntry(1)
+	if err != nil || first.PlanID == "" || first.PlanDay != 2 {
+		t.Fatalf("Expected the entry to be linked to day 2, got %+v, %v", first, err)
+	}
+	runTest(t, "", "plan", "new", "09:00", "--target", "07:00", "--adjustment", "1h", "--start-date", "2025-07-14", "--yes")
+
+	runTest(t, "", "log", "correct", "1", "--note", "restless")
+	if e, _ := findDiaryEntry(1); e.Note != "restless" || e.PlanID != first.PlanID || e.PlanDay != 2 {
+		t.Errorf("Expected a correction to keep the entry's plan, got %+v", e)
+	}
+	runTest(t, "", "log", "correct", "1", "--date", "2025-07-15")
+	if e, _ := findDiaryEntry(1); e.PlanID == first.PlanID || e.PlanDay != 2 {
+		t.Errorf("Expected a moved entry to be linked to the active plan, got %+v", e)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// These match the lines of `dumpsys alarm` that matter for alarm clocks:
+// the heading of each pending alarm and the trigger time of the alarm
+// clocks among them. The format has changed over Android versions; this
+// covers both the batched list of Android 9 and the flat list of later
+// versions.
+var (
+	dumpsysAlarmHeading = regexp.MustCompile(`^\s*\w+ #\d+: Alarm\{\S+ type \d+ (?:origWhen|when) \S+(?: whenElapsed \S+)? \S+\}`)
+	dumpsysTriggerTime  = regexp.MustCompile(`^\s*triggerTime=(\d{4}-\d\d-\d\d \d\d:\d\d:\d\d(?:\.\d+)?)\s*$`)
+)
+
+// parseDumpsysAlarm returns the alarm clocks in the output of `dumpsys
+// alarm`, sorted by time. Those are the alarms a clock app shows, so other
+// alarms apps schedule are left out. The output has no labels, and only the
+// next time of a repeating alarm, given in the wall clock of the device.
+func parseDumpsysAlarm(output string) ([]Alarm, error) {
+	if !strings.Contains(output, "Alarm Manager") {
+		first, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+		return nil, tr.Errorf("unexpected dumpsys alarm output: %s", first)
+	}
+	var alarms []Alarm
+	inAlarm := false
+	for _, line := range strings.Split(output, "\n") {
+		if dumpsysAlarmHeading.MatchString(line) {
+			inAlarm = true
+			continue
+		}
+		m := dumpsysTriggerTime.FindStringSubmatch(line)
+		if m == nil || !inAlarm {
+			continue
+		}
+		t, err := time.Parse(time.DateTime, m[1])
+		if err != nil {
+			return nil, tr.Errorf("error parsing trigger time %q: %w", m[1], err)
+		}
+		if !slices.ContainsFunc(alarms, func(a Alarm) bool { return a.Time.Equal(t) }) {
+			alarms = append(alarms, Alarm{Time: t})
+		}
+		inAlarm = false
+	}
+	slices.SortFunc(alarms, func(x, y Alarm) int { return x.Time.Compare(y.Time) })
+	return alarms, nil
+}
@@ -0,0 +1,51 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDumpsysAlarm(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []string
+	}{
+		{"testdata/dumpsys-alarm-android14.txt", []string{"2025-07-14 07:00", "2025-07-15 06:00", "2025-07-18 06:15"}},
+		{"testdata/dumpsys-alarm-android9.txt", []string{"2025-07-14 07:00", "2025-07-15 06:00"}},
+	}
+	for _, test := range tests {
+		data, err := os.ReadFile(test.fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		alarms, err := parseDumpsysAlarm(string(data))
+		if err != nil {
+			t.Errorf("%s: %v", test.fixture, err)
+			continue
+		}
+		var got []string
+		for _, alarm := range alarms {
+			if alarm.Label != "" || alarm.Time.Location() != time.UTC {
+				t.Errorf("%s: expected unlabelled wall clock times, got %+v", test.fixture, alarm)
+			}
+			got = append(got, alarm.Time.Format("2006-01-02 15:04"))
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: expected %q, got %q", test.fixture, test.want, got)
+		}
+	}
+
+	_, err := parseDumpsysAlarm("Permission Denial: can't dump AlarmManager from pid=4242, uid=10187\n")
+	if err == nil || !strings.Contains(err.Error(), "Permission Denial") {
+		t.Errorf("Expected a permission error to be reported, got %v", err)
+	}
+}
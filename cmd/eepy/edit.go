@@ -0,0 +1,156 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func editCommand(flags *pflag.FlagSet) runFunc {
+	wakeStr := flags.String("wake", "", "Override the wake up time for the day (HH:MM)")
+	bedtimeStr := flags.String("bedtime", "", "Override the bedtime for the day (HH:MM)")
+	note := flags.String("note", "", "Add a note to the day")
+	insert := flags.Bool("insert", false, "Insert a day that repeats this day's wake up time, moving later days back")
+	remove := flags.Bool("remove", false, "Remove the day, moving later days forward")
+	clearDay := flags.Bool("clear", false, "Remove all overrides for the day")
+
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return tr.Errorf("plan edit needs exactly one date (YYYY-MM-DD)")
+		}
+		date, err := parseDate(args[0], timeNow(), time.UTC)
+		if err != nil {
+			return tr.Errorf("error parsing date: %w", err)
+		}
+
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		if err := ensureRevisionBaseline(p); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, p.StartDate.Location())
+		if dayIndex(p.Days(), date) < 0 {
+			return tr.Errorf("%s is not part of the plan", date.Format(dateFormat))
+		}
+
+		var overrides []Override
+		now := timeNow()
+		if *clearDay {
+			days := p.Days()
+			applied := days[dayIndex(days, date)].overrides
+			kept := p.Overrides[:0]
+			for i, o := range p.Overrides {
+				if !slices.Contains(applied, i) {
+					kept = append(kept, o)
+				}
+			}
+			p.Overrides = kept
+		}
+		if *wakeStr != "" {
+			wakeTime, err := parseClock(*wakeStr)
+			if err != nil {
+				return tr.Errorf("error parsing wake: %w", err)
+			}
+			overrides = append(overrides, Override{Kind: overrideWake, Date: date, Time: wakeTime, Created: now})
+		}
+		if *bedtimeStr != "" {
+			bedtime, err := parseClock(*bedtimeStr)
+			if err != nil {
+				return tr.Errorf("error parsing bedtime: %w", err)
+			}
+			overrides = append(overrides, Override{Kind: overrideBedtime, Date: date, Time: bedtime, Created: now})
+		}
+		if flags.Changed("note") {
+			overrides = append(overrides, Override{Kind: overrideNote, Date: date, Note: *note, Created: now})
+		}
+		if *insert {
+			overrides = append(overrides, Override{Kind: overrideInsert, Date: date, Created: now})
+		}
+		if *remove {
+			if len(p.Days()) == 1 {
+				return tr.Errorf("cannot remove the only day of the plan")
+			}
+			overrides = append(overrides, Override{Kind: overrideRemove, Date: date, Created: now})
+		}
+		if len(overrides) == 0 && !*clearDay {
+			return tr.Errorf("nothing to edit; see 'eepy help plan edit'")
+		}
+		p.Overrides = append(p.Overrides, overrides...)
+
+		if err := savePlan(p); err != nil {
+			return tr.Errorf("error saving plan: %w", err)
+		}
+		if err := recordRevision(revisionEdit, p); err != nil {
+			return tr.Errorf("error recording plan revision: %w", err)
+		}
+		displayPlan(a.stdout, p)
+		return nil
+	}
+}
+
+// replanCommand regenerates the schedule of the active plan from new
+// parameters, keeping its start date and any per-day overrides.
+func replanCommand(flags *pflag.FlagSet) runFunc {
+	wakeStr := flags.String("wake", "", "New initial wake up time (HH:MM)")
+	targetStr := flags.String("target", "", "New target wake up time (HH:MM)")
+	adjustmentStr := flags.String("adjustment", "", "New adjustment per day")
+	sleepNeed := flags.Duration("sleep-need", 0, "New sleep need per night")
+
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		if err := ensureRevisionBaseline(p); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+		if *wakeStr != "" {
+			if p.InitialWakeTime, err = parseClock(*wakeStr); err != nil {
+				return tr.Errorf("error parsing wake: %w", err)
+			}
+		}
+		if *targetStr != "" {
+			if p.TargetWakeTime, err = parseClock(*targetStr); err != nil {
+				return tr.Errorf("error parsing target: %w", err)
+			}
+		}
+		if *adjustmentStr != "" {
+			if p.Adjustment, err = time.ParseDuration(*adjustmentStr); err != nil {
+				return tr.Errorf("error parsing adjustment: %w", err)
+			}
+		}
+		if *sleepNeed != 0 {
+			if err := checkSleepNeed(*sleepNeed); err != nil {
+				return err
+			}
+			p.SleepNeed = *sleepNeed
+		}
+		if err := replan(p); err != nil {
+			return err
+		}
+		displayPlan(a.stdout, p)
+		return nil
+	}
+}
+
+// replan regenerates the schedule of the active plan p from its parameters
+// and saves it. Its edits and pauses stay, since they go by date.
+func replan(p *Plan) error {
+	p.Schedule = generateSchedule(p.InitialWakeTime, p.TargetWakeTime, p.Adjustment, p.StartDate)
+	if err := savePlan(p); err != nil {
+		return tr.Errorf("error saving plan: %w", err)
+	}
+	if err := recordRevision(revisionReplan, p); err != nil {
+		return tr.Errorf("error recording plan revision: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// locale is the language and clock preference used for output. Messages
+// are looked up by their English text in the catalogue of the language, so
+// a missing translation falls back to English.
+type locale struct {
+	lang      string
+	weekdays  [7]string
+	months    [12]string
+	dayLayout string
+	clock12   bool
+	weekStart time.Weekday
+	messages  map[string]string
+}
+
+// Placeholders for the weekday and month names in a day layout. They are
+// not layout elements, so time.Format leaves them alone.
+const (
+	weekdayPlaceholder = "\x01"
+	monthPlaceholder   = "\x02"
+)
+
+var locales = map[string]locale{
+	"en": {
+		lang:      "en",
+		weekdays:  [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		months:    [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		dayLayout: weekdayPlaceholder + ", " + monthPlaceholder + " 2",
+		weekStart: time.Monday,
+	},
+	"da": {
+		lang:      "da",
+		weekdays:  [7]string{"søn", "man", "tir", "ons", "tor", "fre", "lør"},
+		months:    [12]string{"jan", "feb", "mar", "apr", "maj", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		dayLayout: weekdayPlaceholder + " 2. " + monthPlaceholder,
+		weekStart: time.Monday,
+		messages:  danish,
+	},
+	"de": {
+		lang:      "de",
+		weekdays:  [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		months:    [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		dayLayout: weekdayPlaceholder + ", 2. " + monthPlaceholder,
+		weekStart: time.Monday,
+		messages:  german,
+	},
+}
+
+// Regions that use a 12-hour clock and that start the week on Sunday.
+var (
+	clock12Regions    = []string{"US", "CA", "AU", "NZ", "PH", "IN"}
+	sundayWeekRegions = []string{"US", "CA", "JP", "PH", "IN", "BR", "IL"}
+)
+
+// tr is the locale used for output. main sets it from the environment;
+// tests get English with a 24-hour clock.
+var tr = func() *locale { l := locales["en"]; return &l }()
+
+// splitLocale returns the language and region of a POSIX locale name like
+// da_DK.UTF-8.
+func splitLocale(name string) (lang, region string) {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	lang, region, _ = strings.Cut(strings.ReplaceAll(name, "-", "_"), "_")
+	return strings.ToLower(lang), strings.ToUpper(region)
+}
+
+// checkLocale checks that there is a catalogue for the language of a locale
+// name. C and POSIX are English.
+func checkLocale(name string) error {
+	lang, _ := splitLocale(name)
+	if _, ok := locales[lang]; !ok && name != "" && name != "C" && name != "POSIX" {
+		return tr.Errorf("unknown locale %q; use a locale for %s, like da_DK", name, strings.Join(slices.Sorted(maps.Keys(locales)), ", "))
+	}
+	return nil
+}
+
+// newLocale returns the locale for a POSIX locale name like da_DK.UTF-8.
+// Unknown languages get English with the clock and week of the region.
+func newLocale(name string) locale {
+	lang, region := splitLocale(name)
+	l, ok := locales[lang]
+	if !ok {
+		l = locales["en"]
+	}
+	l.clock12 = slices.Contains(clock12Regions, region)
+	if slices.Contains(sundayWeekRegions, region) {
+		l.weekStart = time.Sunday
+	}
+	return l
+}
+
+// setLocale sets tr from the environment and the config. An invalid
+// setting is left out with a warning on w, so that every command, and
+// `eepy config` in particular, still runs. A config that cannot be loaded
+// is left for the commands that read it to report.
+func setLocale(getenv func(string) string, w io.Writer) {
+	cfg, _ := loadConfig()
+	l, err := detectLocale(getenv, cfg)
+	if err != nil {
+		tr.Fprintf(w, "Warning: %v\n", err)
+	}
+	tr = &l
+}
+
+// detectLocale picks the locale from the locale setting, then LC_ALL,
+// LC_TIME or LANG, and applies the clock and week-start settings. Only a
+// locale setting brings the 12-hour clock of its region: the environment
+// keeps the 24-hour clock, which scripts reading the output expect.
+func detectLocale(getenv func(string) string, cfg Config) (locale, error) {
+	setting := func(key string) string {
+		value, _ := lookupSetting(key, getenv, cfg)
+		return value
+	}
+	name := setting("locale")
+	explicit := name != ""
+	if err := checkLocale(name); err != nil {
+		return locales["en"], err
+	}
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if name != "" {
+			break
+		}
+		name = getenv(key)
+	}
+	l := newLocale(name)
+	l.clock12 = l.clock12 && explicit
+	err := l.setClock(setting("clock"))
+	return l, errors.Join(err, l.setWeekStart(setting("week-start")))
+}
+
+// setClock sets the clock preference to 12h or 24h. Empty keeps it.
+func (l *locale) setClock(s string) error {
+	switch strings.ToLower(s) {
+	case "":
+	case "12h", "12":
+		l.clock12 = true
+	case "24h", "24":
+		l.clock12 = false
+	default:
+		return tr.Errorf("invalid clock %q: use 12h or 24h", s)
+	}
+	return nil
+}
+
+// setWeekStart sets the first day of the week to Monday or Sunday. Empty
+// keeps it.
+func (l *locale) setWeekStart(s string) error {
+	switch strings.ToLower(s) {
+	case "":
+	case "mon", "monday":
+		l.weekStart = time.Monday
+	case "sun", "sunday":
+		l.weekStart = time.Sunday
+	default:
+		return tr.Errorf("invalid week start %q: use mon or sun", s)
+	}
+	return nil
+}
+
+// T translates a message.
+func (l *locale) T(message string) string {
+	if translated, ok := l.messages[message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf formats the translation of format.
+func (l *locale) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(l.T(format), args...)
+}
+
+// Errorf returns an error with the translation of format. It wraps a %w
+// argument like fmt.Errorf.
+func (l *locale) Errorf(format string, args ...any) error {
+	return fmt.Errorf(l.T(format), args...)
+}
+
+// translatedError is an error whose message is translated when it is
+// printed, for errors that are created before the locale is known. Being a
+// string, it compares equal to itself for errors.Is.
+type translatedError string
+
+func (e translatedError) Error() string {
+	return tr.T(string(e))
+}
+
+// Fprintf writes the translation of format to w.
+func (l *locale) Fprintf(w io.Writer, format string, args ...any) {
+	fmt.Fprintf(w, l.T(format), args...)
+}
+
+// Fprintln writes the translation of message to w as a line.
+func (l *locale) Fprintln(w io.Writer, message string) {
+	fmt.Fprintln(w, l.T(message))
+}
+
+// day formats the date of t as a short day, like "Mon, Jan 2".
+func (l *locale) day(t time.Time) string {
+	s := t.Format(l.dayLayout)
+	s = strings.Replace(s, weekdayPlaceholder, l.weekdays[t.Weekday()], 1)
+	return strings.Replace(s, monthPlaceholder, l.months[t.Month()-1], 1)
+}
+
+// date formats the date of t with the year, like "Mon, Jan 2 2006".
+func (l *locale) date(t time.Time) string {
+	return l.day(t) + " " + t.Format("2006")
+}
+
+// clock formats the wall clock time of t in 12 or 24 hours.
+func (l *locale) clock(t time.Time) string {
+	if l.clock12 {
+		return t.Format("3:04 PM")
+	}
+	return t.Format(timeFormat)
+}
+
+// clockString formats a HH:MM string from the machine-readable outputs.
+func (l *locale) clockString(hhmm string) string {
+	clock, err := parseClock(hhmm)
+	if err != nil {
+		return hhmm
+	}
+	return l.clock(clock)
+}
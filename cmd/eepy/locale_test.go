@@ -0,0 +1,141 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useLocale makes l the output locale for the rest of the test.
+func useLocale(t *testing.T, l locale) {
+	t.Helper()
+	previous := tr
+	tr = &l
+	t.Cleanup(func() { tr = previous })
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		env       map[string]string
+		lang      string
+		clock12   bool
+		weekStart time.Weekday
+	}{
+		{map[string]string{}, "en", false, time.Monday},
+		{map[string]string{"LANG": "C"}, "en", false, time.Monday},
+		{map[string]string{"LANG": "en_US.UTF-8"}, "en", false, time.Sunday},
+		{map[string]string{"LANG": "en_GB.UTF-8", "EEPY_LOCALE": "en_US"}, "en", true, time.Sunday},
+		{map[string]string{"LANG": "en_GB.UTF-8"}, "en", false, time.Monday},
+		{map[string]string{"LANG": "da_DK.UTF-8"}, "da", false, time.Monday},
+		{map[string]string{"LANG": "en_US.UTF-8", "LC_TIME": "de_DE.UTF-8"}, "de", false, time.Monday},
+		{map[string]string{"LC_TIME": "de_DE", "LC_ALL": "da_DK"}, "da", false, time.Monday},
+		{map[string]string{"LC_ALL": "da_DK", "EEPY_LOCALE": "de"}, "de", false, time.Monday},
+		{map[string]string{"LANG": "fr_FR.UTF-8"}, "en", false, time.Monday},
+		{map[string]string{"LANG": "da_DK", "EEPY_CLOCK": "12h", "EEPY_WEEK_START": "sun"}, "da", true, time.Sunday},
+		{map[string]string{"LANG": "en_US", "EEPY_CLOCK": "24h", "EEPY_WEEK_START": "mon"}, "en", false, time.Monday},
+	}
+	for _, test := range tests {
+		l, err := detectLocale(func(key string) string { return test.env[key] }, nil)
+		if err != nil || l.lang != test.lang || l.clock12 != test.clock12 || l.weekStart != test.weekStart {
+			t.Errorf("%v: expected %s, 12h %v, week from %s, got %s, 12h %v, week from %s (%v)",
+				test.env, test.lang, test.clock12, test.weekStart, l.lang, l.clock12, l.weekStart, err)
+		}
+	}
+
+	if _, err := detectLocale(func(key string) string { return map[string]string{"EEPY_CLOCK": "13h"}[key] }, nil); err == nil {
+		t.Error("Expected an invalid clock preference to fail")
+	}
+}
+
+func TestLocaleFormats(t *testing.T) {
+	day := time.Date(2025, 3, 9, 18, 5, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		day   string
+		date  string
+		clock string
+	}{
+		{"en_GB", "Sun, Mar 9", "Sun, Mar 9 2025", "18:05"},
+		{"en_US", "Sun, Mar 9", "Sun, Mar 9 2025", "6:05 PM"},
+		{"da_DK", "søn 9. mar", "søn 9. mar 2025", "18:05"},
+		{"de_DE", "So, 9. Mär", "So, 9. Mär 2025", "18:05"},
+	}
+	for _, test := range tests {
+		l := newLocale(test.name)
+		if got := l.day(day); got != test.day {
+			t.Errorf("%s: expected day %q, got %q", test.name, test.day, got)
+		}
+		if got := l.date(day); got != test.date {
+			t.Errorf("%s: expected date %q, got %q", test.name, test.date, got)
+		}
+		if got := l.clock(day); got != test.clock {
+			t.Errorf("%s: expected clock %q, got %q", test.name, test.clock, got)
+		}
+	}
+}
+
+// TestCatalogues checks that every translation keeps the format verbs of its
+// message, so arguments end up in the right place.
+func TestCatalogues(t *testing.T) {
+	verbs := regexp.MustCompile(`%[+#0-9.]*[a-zA-Z%]`)
+	for lang, catalogue := range map[string]map[string]string{"da": danish, "de": german} {
+		for message, translated := range catalogue {
+			if !slices.Equal(verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1)) {
+				t.Errorf("%s: %q does not keep the verbs of %q", lang, translated, message)
+			}
+			if strings.HasSuffix(message, "\n") != strings.HasSuffix(translated, "\n") {
+				t.Errorf("%s: %q does not keep the line ending of %q", lang, translated, message)
+			}
+		}
+		for message := range danish {
+			if _, ok := catalogue[message]; !ok {
+				t.Errorf("%s: missing translation of %q", lang, message)
+			}
+		}
+	}
+}
+
+func TestLocalizedPlan(t *testing.T) {
+	useLocale(t, newLocale("de_DE.UTF-8"))
+	var b bytes.Buffer
+	displayPlan(&b, testPlan(t))
+	for _, want := range []string{"Dein Plan zur Schlafanpassung:", "So, 13. Jul (Tag 1):", "  - Aufstehen um 10:00", "  - Schlafen gehen um 01:00"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Expected %q in the German plan:\n%s", want, b.String())
+		}
+	}
+
+	useLocale(t, newLocale("en_US"))
+	b.Reset()
+	displayPlan(&b, testPlan(t))
+	if !strings.Contains(b.String(), "  - Wake up at 10:00 AM") {
+		t.Errorf("Expected a 12-hour clock:\n%s", b.String())
+	}
+}
+
+func TestLocalizedErrorsAndHelp(t *testing.T) {
+	setConfigDir(t.TempDir())
+	useLocale(t, newLocale("de_DE.UTF-8"))
+
+	if _, _, stderr := runTest(t, "", "plna"); !strings.Contains(stderr, `Fehler: unbekannter Befehl "plna"`) {
+		t.Errorf("Expected a German error, got %s", stderr)
+	}
+	if _, _, stderr := runTest(t, "", "plan", "show"); !strings.Contains(stderr, "kein aktiver Schlafplan gefunden") {
+		t.Errorf("Expected the missing plan in German, got %s", stderr)
+	}
+	_, stdout, _ := runTest(t, "", "help", "plan", "edit")
+	for _, want := range []string{"Aufruf: eepy plan edit", "Ändere einen einzelnen Tag des Plans.", "Optionen:", "Ändere die Aufstehzeit des Tages (HH:MM)"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in the German help:\n%s", want, stdout)
+		}
+	}
+}
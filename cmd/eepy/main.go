@@ -7,19 +7,15 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"time"
-
-	"github.com/spf13/pflag"
 )
 
 const (
@@ -29,126 +25,73 @@ const (
 )
 
 type Plan struct {
+	ID              string `json:",omitempty"`
 	InitialWakeTime time.Time
 	TargetWakeTime  time.Time
 	Adjustment      time.Duration
+	SleepNeed       time.Duration `json:",omitempty"`
 	Schedule        []time.Time
 	StartDate       time.Time
+	Overrides       []Override `json:",omitempty"`
+	Pauses          []Pause    `json:",omitempty"`
 }
 
 var (
-	configPath string
-	historyPath string
+	configDir       string
+	configPath      string
+	historyPath     string
+	revisionsPath   string
+	diaryPath       string
+	configFilePath  string
+	alarmsPath      string
+	bedtimeModePath string
 )
 
-func main() {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Printf("Error getting home directory: %v\n", err)
-		os.Exit(1)
-	}
-	configDir := filepath.Join(home, ".config", "eepy")
+func setConfigDir(dir string) {
+	configDir = dir
 	configPath = filepath.Join(configDir, "plan.json")
 	historyPath = filepath.Join(configDir, "history")
+	revisionsPath = filepath.Join(configDir, "revisions.json")
+	diaryPath = filepath.Join(configDir, "diary.jsonl")
+	configFilePath = filepath.Join(configDir, "config.json")
+	alarmsPath = filepath.Join(configDir, "alarms.json")
+	bedtimeModePath = filepath.Join(configDir, "bedtime-mode.json")
+}
 
-	if err := os.MkdirAll(historyPath, 0755); err != nil {
-		fmt.Printf("Error creating history directory: %v\n", err)
-		os.Exit(1)
-	}
-
-	targetWakeTimeStr := pflag.String("target", "05:00", "Your target wake up time (HH:MM)")
-	adjustmentStr := pflag.String("adjustment", "1h30m", "Adjustment per day")
-	adb := pflag.BoolP("adb", "a", false, "Set alarm on Android device via ADB. Requires a connected device.")
-	noSkipToday := pflag.Bool("no-skip-today", false, "Do not skip setting an alarm for today")
-	htmlOutput := pflag.Bool("html", false, "Generate an HTML visualization of the plan")
-	startDateStr := pflag.String("start-date", time.Now().Format("2006-01-02"), "The start date of the plan (YYYY-MM-DD)")
-	pflag.Parse()
-
-	startDate, err := time.Parse("2006-01-02", *startDateStr)
-	if err != nil {
-		fmt.Printf("Error parsing start-date: %v\n", err)
-		os.Exit(1)
-	}
-
-	existingPlan, err := loadPlan()
-
-	if len(pflag.Args()) == 0 {
-		if err != nil {
-			fmt.Println("No active sleep plan found. Create one by providing a wake-up time.")
-			fmt.Println("Usage: eepy [wake-time] [flags]")
-			pflag.PrintDefaults()
-			os.Exit(1)
-		}
-		displayPlan(existingPlan)
-		if *htmlOutput {
-			if err := generateHTML(existingPlan); err != nil {
-				fmt.Printf("Error generating HTML: %v\n", err)
-			}
-		}
-		if *adb {
-			setAlarms(existingPlan.Schedule, *noSkipToday)
-		}
-		os.Exit(0)
-	}
-
-	wakeTimeStr := pflag.Arg(0)
-	wakeTime, err := time.Parse(timeFormat, wakeTimeStr)
-	if err != nil {
-		fmt.Printf("Error parsing wake-time: %v\n", err)
-		os.Exit(1)
-	}
-
-	if existingPlan != nil {
-		fmt.Print("An active sleep plan already exists. Do you want to override it? (y/N): ")
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		if strings.ToLower(strings.TrimSpace(input)) != "y" {
-			fmt.Println("Operation cancelled.")
-			os.Exit(0)
-		}
-		if err := archivePlan(existingPlan); err != nil {
-			fmt.Printf("Error archiving existing plan: %v\n", err)
-			os.Exit(1)
-		}
-	}
-
-	targetWakeTime, err := time.Parse(timeFormat, *targetWakeTimeStr)
+func main() {
+	home, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Printf("Error parsing target-wake-time: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
 		os.Exit(1)
 	}
+	setConfigDir(filepath.Join(home, ".config", "eepy"))
 
-	adjustment, err := time.ParseDuration(*adjustmentStr)
-	if err != nil {
-		fmt.Printf("Error parsing adjustment: %v\n", err)
+	if err := os.MkdirAll(historyPath, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating history directory: %v\n", err)
 		os.Exit(1)
 	}
 
-	schedule := generateSchedule(wakeTime, targetWakeTime, adjustment, startDate)
-	newPlan := &Plan{
-		InitialWakeTime: wakeTime,
-		TargetWakeTime:  targetWakeTime,
-		Adjustment:      adjustment,
-		Schedule:        schedule,
-		StartDate:       startDate,
-	}
-
-	if err := savePlan(newPlan); err != nil {
-		fmt.Printf("Error saving new plan: %v\n", err)
-		os.Exit(1)
-	}
+	setLocale(os.Getenv, os.Stderr)
 
-	displayPlan(newPlan)
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
 
-	if *htmlOutput {
-		if err := generateHTML(newPlan); err != nil {
-			fmt.Printf("Error generating HTML: %v\n", err)
-		}
+// sleepNeed is how long the plan has you sleep each night. Plans from before
+// it could be chosen use idealSleepDuration.
+func (p *Plan) sleepNeed() time.Duration {
+	if p.SleepNeed > 0 {
+		return p.SleepNeed
 	}
+	return idealSleepDuration
+}
 
-	if *adb {
-		setAlarms(newPlan.Schedule, *noSkipToday)
+// id identifies the plan in records kept outside of it. Plans saved before
+// they had an ID are identified by their start date.
+func (p *Plan) id() string {
+	if p.ID != "" {
+		return p.ID
 	}
+	return p.StartDate.Format(dateFormat)
 }
 
 func generateSchedule(wakeTime, targetWakeTime time.Time, adjustment time.Duration, startDate time.Time) []time.Time {
@@ -180,70 +123,37 @@ func generateSchedule(wakeTime, targetWakeTime time.Time, adjustment time.Durati
 	return schedule
 }
 
-func displayPlan(p *Plan) {
-	fmt.Println("Your sleep calibration plan:")
-	fmt.Println("-----------------------------")
-	fmt.Printf("Ideal sleep: %.1f hours. Minimum functional sleep: %.1f hours.\n", idealSleepDuration.Hours(), minSleepDuration.Hours())
-	fmt.Println("-----------------------------")
-	for i, wakeTime := range p.Schedule {
-		dayOfPlan := p.StartDate.AddDate(0, 0, i)
-		bedtime := wakeTime.Add(-idealSleepDuration)
-		fmt.Printf("%s (Day %d):\n", dayOfPlan.Format("Mon, Jan 2"), i+1)
-		fmt.Printf("  - Wake up at %s\n", wakeTime.Format(timeFormat))
-		fmt.Printf("  - Go to bed at %s\n", bedtime.Format(timeFormat))
+func displayPlan(w io.Writer, p *Plan) {
+	tr.Fprintln(w, "Your sleep calibration plan:")
+	fmt.Fprintln(w, "-----------------------------")
+	tr.Fprintf(w, "Ideal sleep: %.1f hours. Minimum functional sleep: %.1f hours.\n", p.sleepNeed().Hours(), minSleepDuration.Hours())
+	fmt.Fprintln(w, "-----------------------------")
+	if pause := p.ongoingPause(); pause != nil {
+		tr.Fprintf(w, "Paused since %s. The dates below assume you resume tomorrow.\n", tr.day(pause.Start))
+		fmt.Fprintln(w, "-----------------------------")
+	}
+	days := p.Days()
+	for _, day := range days {
+		tr.Fprintf(w, "%s (Day %d)%s:\n", tr.day(day.Date), day.Number, overrideMark(day.Inserted, " [inserted]"))
+		tr.Fprintf(w, "  - Wake up at %s%s\n", tr.clock(day.Wake), overrideMark(day.WakeOverridden, " (edited)"))
+		tr.Fprintf(w, "  - Go to bed at %s%s\n", tr.clock(day.Bedtime), overrideMark(day.BedtimeOverridden, " (edited)"))
+		if day.Note != "" {
+			tr.Fprintf(w, "  - Note: %s\n", day.Note)
+		}
 	}
-	fmt.Println("-----------------------------")
-	if !p.Schedule[len(p.Schedule)-1].After(p.TargetWakeTime) {
-		fmt.Println("You have reached your target sleep schedule!")
+	fmt.Fprintln(w, "-----------------------------")
+	if p.ReachesTarget(days[len(days)-1].Wake) {
+		tr.Fprintln(w, "You have reached your target sleep schedule!")
 	}
 }
 
-func setAlarms(plan []time.Time, noSkipToday bool) {
-	if len(plan) > 7 {
-		fmt.Println("Error: Cannot schedule alarms for a plan longer than 7 days.")
-		os.Exit(1)
-	}
-
-	if !noSkipToday && len(plan) > 0 {
-		plan = plan[1:]
-	}
-
-	fmt.Println("Setting alarms via ADB...")
-
-	for _, wakeTime := range plan {
-		hour := wakeTime.Hour()
-		minute := wakeTime.Minute()
-		dayOfWeek := wakeTime.Weekday()
-		androidDay := int(dayOfWeek) + 1
-
-		fmt.Printf("Setting alarm for %s: %02d:%02d\n", wakeTime.Format("Mon, Jan 2"), hour, minute)
-
-		args := []string{
-			"shell", "am", "start",
-			"-a", "android.intent.action.SET_ALARM",
-			"-f", "0x10000000",
-			"--ei", "android.intent.extra.alarm.HOUR", strconv.Itoa(hour),
-			"--ei", "android.intent.extra.alarm.MINUTES", strconv.Itoa(minute),
-			"--eia", "android.intent.extra.alarm.DAYS", strconv.Itoa(androidDay),
-			"--es", "android.intent.extra.alarm.MESSAGE", fmt.Sprintf("'Sleep Adjustment Wake Up: %s'", wakeTime.Format("Mon, Jan 2")),
-		}
-
-		cmd := exec.Command("adb", args...)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			fmt.Printf("Error executing adb command for %s: %v\n", wakeTime.Format("Mon, Jan 2"), err)
-			fmt.Printf("Output: %s\n", string(output))
-		} else {
-			fmt.Printf("Alarm for %s sent successfully.\n", wakeTime.Format("Mon, Jan 2"))
-			if len(output) > 0 {
-				fmt.Printf("Output: %s\n", string(output))
-			}
-		}
-		time.Sleep(1 * time.Second)
+func overrideMark(overridden bool, mark string) string {
+	if overridden {
+		return tr.T(mark)
 	}
+	return ""
 }
 
-
 func savePlan(p *Plan) error {
 	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
@@ -253,7 +163,11 @@ func savePlan(p *Plan) error {
 }
 
 func loadPlan() (*Plan, error) {
-	data, err := ioutil.ReadFile(configPath)
+	return loadPlanFile(configPath)
+}
+
+func loadPlanFile(path string) (*Plan, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -262,23 +176,26 @@ func loadPlan() (*Plan, error) {
 	return &p, err
 }
 
-func archivePlan(p *Plan) error {
+func archivePlan(p *Plan) (string, error) {
+	if err := os.MkdirAll(historyPath, 0755); err != nil {
+		return "", err
+	}
 	files, err := ioutil.ReadDir(historyPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 	newFileName := fmt.Sprintf("plan-%d.json", len(files)+1)
 	newPath := filepath.Join(historyPath, newFileName)
-	return os.Rename(configPath, newPath)
+	return newFileName, os.Rename(configPath, newPath)
 }
 
 const htmlTemplate = `
 <!DOCTYPE html>
-<html lang="en">
+<html lang="{{.Lang}}">
 <head>
 <meta charset="UTF-8">
 <meta name="viewport" content="width=device-width, initial-scale=1.0">
-<title>Your Sleep Calibration Plan</title>
+<title>{{T "Your Sleep Calibration Plan"}}</title>
 <script src="https://cdn.jsdelivr.net/npm/chart.js"></script>
 <style>
   body {
@@ -393,27 +310,35 @@ const htmlTemplate = `
     height: 100%;
     background-color: #4a5568;
   }
+  .override {
+    color: #c05621;
+    font-weight: 600;
+  }
+  .note {
+    font-size: 0.85rem;
+    color: #718096;
+  }
 </style>
 </head>
 <body>
   <div class="container">
     <header>
-      <h1>Your Sleep Calibration Plan</h1>
+      <h1>{{T "Your Sleep Calibration Plan"}}</h1>
     </header>
     <div class="summary">
       <div class="summary-item">
-        <span>Days to Target</span>
-        <span>{{.DaysToTarget}} days</span>
+        <span>{{T "Days to Target"}}</span>
+        <span>{{Sprintf "%d days" .DaysToTarget}}</span>
       </div>
       <div class="summary-item">
-        <span>Adjustment per Day</span>
+        <span>{{T "Adjustment per Day"}}</span>
         <span>{{.Adjustment}}</span>
       </div>
       <div class="donut-chart-container">
         <canvas id="progressDonutChart"></canvas>
         <div class="donut-chart-label">
           {{printf "%.0f%%" .Progress}}
-          <div class="donut-chart-sub-label">Progress</div>
+          <div class="donut-chart-sub-label">{{T "Progress"}}</div>
         </div>
       </div>
     </div>
@@ -428,19 +353,22 @@ const htmlTemplate = `
     <table>
       <thead>
         <tr>
-          <th><span class="emoji">📅</span>Date</th>
-          <th><span class="emoji">⏰</span>Wake Up</th>
-          <th><span class="emoji">😴</span>Bedtime</th>
-          <th><span class="emoji">⏳</span>Duration</th>
-          <th><span class="emoji">📊</span>Sleep Period</th>
+          <th><span class="emoji">📅</span>{{T "Date"}}</th>
+          <th><span class="emoji">⏰</span>{{T "Wake Up"}}</th>
+          <th><span class="emoji">😴</span>{{T "Bedtime"}}</th>
+          <th><span class="emoji">⏳</span>{{T "Duration"}}</th>
+          <th><span class="emoji">📊</span>{{T "Sleep Period"}}</th>
         </tr>
       </thead>
       <tbody>
         {{range .Schedule}}
         <tr>
-          <td>{{.Date}}</td>
-          <td>{{.WakeTime}}</td>
-          <td>{{.Bedtime}}</td>
+          <td>
+            {{.Date}}{{if .Inserted}} <span class="override">{{T "(inserted)"}}</span>{{end}}
+            {{if .Note}}<div class="note">{{.Note}}</div>{{end}}
+          </td>
+          <td{{if .WakeOverridden}} class="override" title="{{T "Edited"}}"{{end}}>{{.WakeTime}}{{if .WakeOverridden}} ✎{{end}}</td>
+          <td{{if .BedtimeOverridden}} class="override" title="{{T "Edited"}}"{{end}}>{{.Bedtime}}{{if .BedtimeOverridden}} ✎{{end}}</td>
           <td>{{.Duration}}</td>
           <td>
             <div class="timeline">
@@ -471,7 +399,7 @@ const htmlTemplate = `
   new Chart(progressDonutCtx, {
     type: 'doughnut',
     data: {
-      labels: ['Completed', 'Remaining'],
+      labels: [{{T "Completed"}}, {{T "Remaining"}}],
       datasets: [{
         data: [{{.Progress}}, 100 - {{.Progress}}],
         backgroundColor: ['#4a5568', '#e2e8f0'],
@@ -497,7 +425,7 @@ const htmlTemplate = `
       labels: {{.ChartLabels}},
       datasets: [
         {
-          label: 'Wake Up Time',
+          label: {{T "Wake Up Time"}},
           data: {{.WakeUpData}},
           borderColor: '#4a5568',
           backgroundColor: 'rgba(74, 85, 104, 0.2)',
@@ -505,7 +433,7 @@ const htmlTemplate = `
           tension: 0.1
         },
         {
-          label: 'Bedtime',
+          label: {{T "Bedtime"}},
           data: {{.BedtimeData}},
           borderColor: '#a0aec0',
           backgroundColor: 'rgba(160, 174, 192, 0.2)',
@@ -530,7 +458,7 @@ const htmlTemplate = `
     data: {
       labels: {{.ChartLabels}},
       datasets: [{
-        label: 'Sleep Duration (hours)',
+        label: {{T "Sleep Duration (hours)"}},
         data: {{.DurationData}},
         backgroundColor: '#4a5568'
       }]
@@ -559,9 +487,15 @@ type ScheduleEntry struct {
 	Bedtime     string
 	Duration    string
 	SleepBlocks []SleepBlock
+
+	Note              string
+	Inserted          bool
+	WakeOverridden    bool
+	BedtimeOverridden bool
 }
 
 type TemplateData struct {
+	Lang         string
 	DaysToTarget int
 	Adjustment   string
 	Schedule     []ScheduleEntry
@@ -572,13 +506,53 @@ type TemplateData struct {
 	Progress     float64
 }
 
-func generateHTML(p *Plan) error {
+// planProgress is how far the wake up time planned for now has moved from
+// the initial wake up time toward the target, in percent.
+func planProgress(p *Plan, days []Day, now time.Time) float64 {
+	var currentScheduledWakeTime time.Time
+	for _, day := range days {
+		if !day.Wake.After(now) {
+			currentScheduledWakeTime = day.Wake
+		} else {
+			break
+		}
+	}
+
+	if currentScheduledWakeTime.IsZero() {
+		currentScheduledWakeTime = days[0].Wake
+	}
+
+	commonDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	initialTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), p.InitialWakeTime.Hour(), p.InitialWakeTime.Minute(), 0, 0, time.UTC)
+	targetTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), p.TargetWakeTime.Hour(), p.TargetWakeTime.Minute(), 0, 0, time.UTC)
+	currentTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), currentScheduledWakeTime.Hour(), currentScheduledWakeTime.Minute(), 0, 0, time.UTC)
+
+	totalAdjustment := initialTime.Sub(targetTime)
+	adjustedSoFar := initialTime.Sub(currentTime)
+
+	progress := 0.0
+	if totalAdjustment > 0 {
+		progress = (float64(adjustedSoFar) / float64(totalAdjustment)) * 100
+	}
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 100 {
+		progress = 100
+	}
+	return progress
+}
+
+// generateHTML writes the HTML report of p to a temporary file and opens it
+// unless open is false.
+func generateHTML(w io.Writer, p *Plan, open bool) error {
 	var schedule []ScheduleEntry
 	var chartLabels []string
 	var wakeUpData, bedtimeData, durationData []float64
 
-	for _, wakeTime := range p.Schedule {
-		bedtime := wakeTime.Add(-idealSleepDuration)
+	days := p.Days()
+	for _, day := range days {
+		wakeTime, bedtime := day.Wake, day.Bedtime
 		duration := wakeTime.Sub(bedtime)
 
 		var blocks []SleepBlock
@@ -596,54 +570,29 @@ func generateHTML(p *Plan) error {
 		}
 
 		schedule = append(schedule, ScheduleEntry{
-			Date:        wakeTime.Format("Mon, Jan 2"),
-			WakeTime:    wakeTime.Format(timeFormat),
-			Bedtime:     bedtime.Format(timeFormat),
-			Duration:    fmt.Sprintf("%.1f hours", duration.Hours()),
+			Date:        tr.day(wakeTime),
+			WakeTime:    tr.clock(wakeTime),
+			Bedtime:     tr.clock(bedtime),
+			Duration:    tr.Sprintf("%.1f hours", duration.Hours()),
 			SleepBlocks: blocks,
+
+			Note:              day.Note,
+			Inserted:          day.Inserted,
+			WakeOverridden:    day.WakeOverridden,
+			BedtimeOverridden: day.BedtimeOverridden,
 		})
 
-		chartLabels = append(chartLabels, "`"+wakeTime.Format("Jan 2")+"`")
+		chartLabels = append(chartLabels, "`"+tr.day(wakeTime)+"`")
 		wakeUpData = append(wakeUpData, float64(wakeTime.Hour())+float64(wakeTime.Minute())/60.0)
 		bedtimeData = append(bedtimeData, float64(bedtime.Hour())+float64(bedtime.Minute())/60.0)
 		durationData = append(durationData, duration.Hours())
 	}
 
-	now := time.Now()
-	var currentScheduledWakeTime time.Time
-	for _, wakeTime := range p.Schedule {
-		if !wakeTime.After(now) {
-			currentScheduledWakeTime = wakeTime
-		} else {
-			break
-		}
-	}
-
-	if currentScheduledWakeTime.IsZero() {
-		currentScheduledWakeTime = p.Schedule[0]
-	}
-
-	commonDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
-	initialTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), p.InitialWakeTime.Hour(), p.InitialWakeTime.Minute(), 0, 0, time.UTC)
-	targetTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), p.TargetWakeTime.Hour(), p.TargetWakeTime.Minute(), 0, 0, time.UTC)
-	currentTime := time.Date(commonDate.Year(), commonDate.Month(), commonDate.Day(), currentScheduledWakeTime.Hour(), currentScheduledWakeTime.Minute(), 0, 0, time.UTC)
-
-	totalAdjustment := initialTime.Sub(targetTime)
-	adjustedSoFar := initialTime.Sub(currentTime)
-
-	progress := 0.0
-	if totalAdjustment > 0 {
-		progress = (float64(adjustedSoFar) / float64(totalAdjustment)) * 100
-	}
-	if progress < 0 {
-		progress = 0
-	}
-	if progress > 100 {
-		progress = 100
-	}
+	progress := planProgress(p, days, timeNow())
 
 	data := TemplateData{
-		DaysToTarget: len(p.Schedule),
+		Lang:         tr.lang,
+		DaysToTarget: len(days),
 		Adjustment:   p.Adjustment.String(),
 		Schedule:     schedule,
 		ChartLabels:  chartLabels,
@@ -653,28 +602,31 @@ func generateHTML(p *Plan) error {
 		Progress:     progress,
 	}
 
-	tmpl, err := template.New("schedule").Parse(htmlTemplate)
+	tmpl, err := template.New("schedule").Funcs(template.FuncMap{"T": tr.T, "Sprintf": tr.Sprintf}).Parse(htmlTemplate)
 	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
+		return tr.Errorf("error parsing template: %w", err)
 	}
 
 	tmpfile, err := ioutil.TempFile("", "sleep-schedule-*.html")
 	if err != nil {
-		return fmt.Errorf("error creating temp file: %w", err)
+		return tr.Errorf("error creating temp file: %w", err)
 	}
 	defer tmpfile.Close()
 
 	err = tmpl.Execute(tmpfile, data)
 	if err != nil {
-		return fmt.Errorf("error executing template: %w", err)
+		return tr.Errorf("error executing template: %w", err)
 	}
 
-	fmt.Printf("Generated HTML report: %s\n", tmpfile.Name())
+	tr.Fprintf(w, "Generated HTML report: %s\n", tmpfile.Name())
+	if !open {
+		return nil
+	}
 
 	cmd := exec.Command("xdg-open", tmpfile.Name())
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("error opening file with xdg-open: %w", err)
+		return tr.Errorf("error opening file with xdg-open: %w", err)
 	}
 
 	return nil
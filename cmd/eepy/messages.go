@@ -0,0 +1,849 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+// danish is the Danish message catalogue, keyed by the English message.
+var danish = map[string]string{
+	// Plan
+	"Your sleep calibration plan:":                                     "Din plan for søvnjustering:",
+	"Ideal sleep: %.1f hours. Minimum functional sleep: %.1f hours.\n": "Ideel søvn: %.1f timer. Minimal funktionel søvn: %.1f timer.\n",
+	"Paused since %s. The dates below assume you resume tomorrow.\n":   "Sat på pause siden %s. Datoerne herunder forudsætter, at du fortsætter i morgen.\n",
+	"%s (Day %d)%s:\n":        "%s (dag %d)%s:\n",
+	" [inserted]":             " [indsat]",
+	" (edited)":               " (redigeret)",
+	"  - Wake up at %s%s\n":   "  - Stå op kl. %s%s\n",
+	"  - Go to bed at %s%s\n": "  - Gå i seng kl. %s%s\n",
+	"  - Note: %s\n":          "  - Note: %s\n",
+	"You have reached your target sleep schedule!":                     "Du har nået din ønskede søvnrytme!",
+	"An active sleep plan already exists. Do you want to override it?": "Der findes allerede en aktiv søvnplan. Vil du erstatte den?",
+	"(y/N):":               "(j/N):",
+	"y":                    "j",
+	"yes":                  "ja",
+	"Operation cancelled.": "Handlingen blev annulleret.",
+	"Schedule":             "Plan",
+	"History":              "Historik",
+	"Diary":                "Dagbog",
+	"eepy plan editor":     "eepy-planlægning",
+	"Current wake up":      "Nuværende opvågning",
+	"Target wake up":       "Mål for opvågning",
+	"Adjustment per day":   "Justering pr. dag",
+	"Start date":           "Startdato",
+	"Sleep need":           "Søvnbehov",
+	"↑↓ scroll  tab switch view  s save  q cancel":                                         "↑↓ rul  tab skift visning  s gem  q annullér",
+	"↑↓ select  ←→ change  PgUp/PgDn scroll  tab switch view  s save  q cancel":            "↑↓ vælg  ←→ ændr  PgUp/PgDn rul  tab skift visning  s gem  q annullér",
+	"Saving replaces the active plan; 'eepy plan undo' brings it back.":                    "At gemme erstatter den aktive plan; 'eepy plan undo' henter den tilbage.",
+	"Saving replans the active plan and keeps its edits; 'eepy plan undo' brings it back.": "At gemme laver den aktive plan om og beholder dens ændringer; 'eepy plan undo' henter den tilbage.",
+	"The new start date drops the edits and pauses of the active plan. Save anyway?":       "Den nye startdato fjerner den aktive plans ændringer og pauser. Vil du gemme alligevel?",
+	"No replaced plans yet.":                                                        "Ingen erstattede planer endnu.",
+	"#%d %s: %s to %s over %d days (replaced %s)":                                   "#%d %s: %s til %s over %d dage (erstattet %s)",
+	"No active sleep plan found. Create one by providing a wake-up time.":           "Ingen aktiv søvnplan fundet. Opret en ved at angive et tidspunkt, du står op.",
+	"Plan paused from %s. Run 'eepy plan resume' to continue where you left off.\n": "Planen er sat på pause fra %s. Kør 'eepy plan resume' for at fortsætte, hvor du slap.\n",
+	"Undid %s from %s.\n":                                                           "Fortrød %s fra %s.\n",
+	"Redid %s from %s.\n":                                                           "Gentog %s fra %s.\n",
+	"create":                                                                        "oprettelse",
+	"edit":                                                                          "redigering",
+	"replan":                                                                        "ny planlægning",
+	"override":                                                                      "erstatning",
+	"pause":                                                                         "pause",
+	"resume":                                                                        "genoptagelse",
+
+	// Configuration
+	"Configuration directory: %s\n":             "Konfigurationsmappe:     %s\n",
+	"Config file:             %s\n":             "Konfigurationsfil:       %s\n",
+	"Set %s to %s.\n":                           "Satte %s til %s.\n",
+	"Unset %s.\n":                               "Fjernede %s.\n",
+	"Note: %s is set and takes precedence.\n":   "Bemærk: %s er sat og har forrang.\n",
+	"Active plan:             %s\n":             "Aktiv plan:              %s\n",
+	"Replaced plans:          %s\n":             "Erstattede planer:       %s\n",
+	"Revision log:            %s\n":             "Revisionslog:            %s\n",
+	"Sleep diary:             %s\n":             "Søvndagbog:              %s\n",
+	"Alarms set by eepy:      %s\n":             "Alarmer sat af eepy:     %s\n",
+	"Settings before bedtime: %s\n":             "Indstillinger før sengetid: %s\n",
+	"%s is already in bedtime mode.\n":          "%s er allerede i sengetidstilstand.\n",
+	"Error switching %s: %v\n":                  "Fejl ved skift af %s: %v\n",
+	"Switched %s to bedtime mode.\n":            "%s er skiftet til sengetidstilstand.\n",
+	"Switched %s back from bedtime mode.\n":     "%s er skiftet tilbage fra sengetidstilstand.\n",
+	"It is not bedtime; %s is left as it is.\n": "Det er ikke sengetid; %s forbliver som den er.\n",
+	"No device settings to restore.":            "Ingen enhedsindstillinger at gendanne.",
+	"Restored the settings of %s.\n":            "Indstillingerne for %s er gendannet.\n",
+
+	// Alarms
+	"Setting alarms via %s...\n":                                 "Sætter alarmer via %s...\n",
+	"Skipping alarm for %s: the plan is paused.\n":               "Springer alarmen for %s over: planen er sat på pause.\n",
+	"Setting alarm for %s: %s\n":                                 "Sætter alarm for %s: %s\n",
+	"Sleep Adjustment Wake Up: %s":                               "Søvnjustering, stå op: %s",
+	"%s (pre-alarm)":                                             "%s (forvarsel)",
+	"%s (backup)":                                                "%s (reserve)",
+	"%d alarms set, %d dismissed, %d failed":                     "%d alarmer sat, %d slået fra, %d fejlede",
+	"Sleep plan alarms":                                          "Alarmer for søvnplanen",
+	"Error posting notification: %v\n":                           "Fejl ved visning af notifikation: %v\n",
+	"Bedtime: %s":                                                "Sengetid: %s",
+	"Bedtime in %s: %s":                                          "Sengetid om %s: %s",
+	"Cancel %q on the device yourself: %v\n":                     "Annullér %q på enheden selv: %v\n",
+	"Reminder %q is already set.\n":                              "Påmindelsen %q er allerede sat.\n",
+	"Setting reminder %q for %s\n":                               "Sætter påmindelsen %q til %s\n",
+	"Error setting reminder %q: %v\n":                            "Fejl ved påmindelsen %q: %v\n",
+	"Reminder %q sent successfully.\n":                           "Påmindelsen %q er sendt.\n",
+	"Error setting alarm for %s: %v\n":                           "Fejl ved indstilling af alarmen for %s: %v\n",
+	"Alarm for %s sent successfully.\n":                          "Alarmen for %s blev sendt.\n",
+	"Summary:":                                                   "Opsummering:",
+	"  %s: %d alarms set, %d dismissed, %d failed\n":             "  %s: %d alarmer sat, %d slået fra, %d fejlede\n",
+	"Dismissed alarm %q.\n":                                      "Slog alarmen %q fra.\n",
+	"Alarm for %s is already set.\n":                             "Alarmen for %s er allerede sat.\n",
+	"  %s: not reachable: %v\n":                                  "  %s: kan ikke nås: %v\n",
+	"No devices connected.":                                      "Ingen enheder tilsluttet.",
+	"Dismissed %d alarms via %s.\n":                              "Slog %d alarmer fra via %s.\n",
+	"Error dismissing alarm %q: %v\n":                            "Fejl ved fjernelse af alarmen %q: %v\n",
+	"Could not reach %s to dismiss its alarms: %v\n":             "Kunne ikke nå %s for at slå dens alarmer fra: %v\n",
+	"No alarms to clear.":                                        "Ingen alarmer at fjerne.",
+	"The plan has finished, so its alarms are no longer needed.": "Planen er slut, så dens alarmer er ikke længere nødvendige.",
+	"Warning: %v\n":                                              "Advarsel: %v\n",
+	"%s: the alarms match the plan.\n":                           "%s: alarmerne passer med planen.\n",
+	"  Missing:    %s\n":                                         "  Mangler:    %s\n",
+	"  Mismatched: %s is set for %s\n":                           "  Afviger:    %s er sat til %s\n",
+	"  Extra:      %s\n":                                         "  Ekstra:     %s\n",
+
+	// HTML report
+	"Generated HTML report: %s\n": "HTML-rapport genereret: %s\n",
+	"Error generating HTML: %v\n": "Fejl ved generering af HTML: %v\n",
+	"Your Sleep Calibration Plan": "Din plan for søvnjustering",
+	"Days to Target":              "Dage til målet",
+	"%d days":                     "%d dage",
+	"Adjustment per Day":          "Justering pr. dag",
+	"Progress":                    "Fremskridt",
+	"Date":                        "Dato",
+	"Wake Up":                     "Stå op",
+	"Bedtime":                     "Sengetid",
+	"Duration":                    "Varighed",
+	"Sleep Period":                "Søvnperiode",
+	"(inserted)":                  "(indsat)",
+	"Edited":                      "Redigeret",
+	"Completed":                   "Gennemført",
+	"Remaining":                   "Tilbage",
+	"Wake Up Time":                "Tidspunkt for at stå op",
+	"Sleep Duration (hours)":      "Søvnlængde (timer)",
+	"%.1f hours":                  "%.1f timer",
+
+	// Diary
+	"Logged entry #%d.\n":    "Registrerede post #%d.\n",
+	"Corrected entry #%d.\n": "Rettede post #%d.\n",
+	"Deleted entry #%d.\n":   "Slettede post #%d.\n",
+	"No diary entries found. Record one with 'eepy log add'.": "Ingen poster i dagbogen. Registrér en med 'eepy log add'.",
+	" (Day %d)":                " (dag %d)",
+	"In bed at":                "I seng kl.",
+	"Asleep at":                "Faldt i søvn kl.",
+	"Woke up at":               "Vågnede kl.",
+	"Out of bed at":            "Stod op kl.",
+	"  - Awake at %s for %s\n": "  - Vågen kl. %s i %s\n",
+	"  - Slept %.1f hours\n":   "  - Sov %.1f timer\n",
+	"  - Quality %d/5\n":       "  - Kvalitet %d/5\n",
+	"Your sleep statistics:":   "Din søvnstatistik:",
+	"No nights logged. Record one with 'eepy log add'.":                                   "Ingen nætter registreret. Registrér en med 'eepy log add'.",
+	"Nights logged: %d (%d on the plan)\n":                                                "Registrerede nætter: %d (%d i planen)\n",
+	"Mean wake up deviation: %+.0f minutes\n":                                             "Gennemsnitlig afvigelse ved opvågning: %+.0f minutter\n",
+	"Mean bedtime deviation: %+.0f minutes\n":                                             "Gennemsnitlig afvigelse ved sengetid: %+.0f minutter\n",
+	"Nights within %d minutes of the plan: %.0f%%\n":                                      "Nætter inden for %d minutter af planen: %.0f%%\n",
+	"Average sleep: %.1f hours (minimum functional sleep: %.1f hours, %d nights below)\n": "Gennemsnitlig søvn: %.1f timer (minimal funktionel søvn: %.1f timer, %d nætter under)\n",
+	"Sleep debt: %.1f hours\n":                                                            "Søvnunderskud: %.1f timer\n",
+	"Sleep Regularity Index: %.0f\n":                                                      "Søvnregelmæssighedsindeks: %.0f\n",
+	"Social jet lag: %.0f minutes\n":                                                      "Social jetlag: %.0f minutter\n",
+
+	// Status
+	"Your plan has not started yet.":                          "Din plan er ikke startet endnu.",
+	"Your plan is paused. Resume it with 'eepy plan resume'.": "Din plan er sat på pause. Fortsæt den med 'eepy plan resume'.",
+	"Your plan is complete. Keep to your target schedule.":    "Din plan er gennemført. Hold dig til din ønskede søvnrytme.",
+	"Day %d of %d.\n":                          "Dag %d af %d.\n",
+	"Progress toward %s":                       "Fremskridt mod %s",
+	"Wake up time today: %s\n":                 "Stå op i dag: %s\n",
+	"Bedtime: %s, %s. You should be asleep.\n": "Sengetid: %s, %s. Du burde sove.\n",
+	"Bedtime: %s, %s\n":                        "Sengetid: %s, %s\n",
+	"Next wake up: %s\n":                       "Næste gang du står op: %s\n",
+	"%s ago":                                   "for %s siden",
+	"in %s":                                    "om %s",
+	"paused":                                   "på pause",
+	"starts soon, bed %s %s":                   "starter snart, seng %s %s",
+	"done":                                     "færdig",
+	"day %d/%d":                                "dag %d/%d",
+	"%s, bed %s %s, wake %s":                   "%s, seng %s %s, op %s",
+	"eepy paused":                              "eepy på pause",
+	"eepy: no plan":                            "eepy: ingen plan",
+	"bed %s %s":                                "seng %s %s",
+
+	// Help and errors
+	"Usage: %s <command>\n\n": "Brug: %s <kommando>\n\n",
+	"Usage: %s %s\n\n":        "Brug: %s %s\n\n",
+	"Usage: %s [flags]\n\n":   "Brug: %s [flag]\n\n",
+	"\nCommands:":             "\nKommandoer:",
+	"\nRun '%s help <command>' for more about a command.\n":                      "\nKør '%s help <kommando>' for at læse mere om en kommando.\n",
+	"\nThe older form 'eepy [wake-time] [flags]' still creates or shows a plan.": "\nDen ældre form 'eepy [wake-time] [flag]' opretter eller viser stadig en plan.",
+	"\nFlags:\n%s":                                                                        "\nFlag:\n%s",
+	"Calibrate your sleep schedule":                                                       "Justér din søvnrytme",
+	"Create, show and change your sleep plan":                                             "Opret, vis og ændr din søvnplan",
+	"Create a plan from your current wake up time":                                        "Opret en plan ud fra det tidspunkt, du står op nu",
+	"Show the active plan":                                                                "Vis den aktive plan",
+	"Override a single day of the plan":                                                   "Ændr en enkelt dag i planen",
+	"Regenerate the plan from new parameters, keeping edits":                              "Lav planen om med nye parametre og behold ændringerne",
+	"Put the plan on hold":                                                                "Sæt planen på pause",
+	"Continue a paused plan":                                                              "Fortsæt en plan på pause",
+	"Restore the previous revision of the plan":                                           "Gendan den forrige udgave af planen",
+	"Reapply a revision that was undone":                                                  "Anvend en fortrudt udgave igen",
+	"Manage wake up alarms":                                                               "Administrér vækkeure",
+	"Set alarms for the active plan":                                                      "Sæt alarmer for den aktive plan",
+	"Check that the alarms on the device match the plan":                                  "Kontrollér, at alarmerne på enheden passer med planen",
+	"Dismiss the alarms eepy has set":                                                     "Afvis de alarmer, eepy har sat",
+	"List the devices alarms can be set on":                                               "Vis de enheder, der kan sættes alarmer på",
+	"Switch devices to Do Not Disturb and grayscale at night":                             "Sæt enheder på Forstyr ikke og gråtoner om natten",
+	"Switch devices into or out of bedtime mode for the time of night":                    "Sæt enheder i eller ud af sengetidstilstand efter tidspunktet",
+	"Restore the settings bedtime mode changed":                                           "Gendan de indstillinger, sengetidstilstand ændrede",
+	"Generate reports about the plan":                                                     "Lav rapporter om planen",
+	"Generate and open an HTML visualization of the plan":                                 "Lav og åbn en HTML-visualisering af planen",
+	"Keep a diary of how you slept":                                                       "Før dagbog over, hvordan du sov",
+	"Record a night":                                                                      "Registrér en nat",
+	"List recorded nights":                                                                "Vis registrerede nætter",
+	"Correct a recorded night":                                                            "Ret en registreret nat",
+	"Delete a recorded night":                                                             "Slet en registreret nat",
+	"Show today's wake up time, tonight's bedtime and how long until it":                  "Vis dagens tid for at stå op, aftenens sengetid og hvor længe der er til",
+	"Print the bedtime countdown for waybar, i3blocks or polybar":                         "Skriv nedtællingen til sengetid for waybar, i3blocks eller polybar",
+	"Try out plans in a full-screen editor":                                               "Afprøv planer i en fuldskærmseditor",
+	"Compare your diary with the plan":                                                    "Sammenlign din dagbog med planen",
+	"List replaced plans or show one of them":                                             "Vis erstattede planer eller en af dem",
+	"Inspect and change eepy's configuration":                                             "Se og ændr eepys konfiguration",
+	"List the settings, their values and where they come from":                            "Vis indstillingerne, deres værdier og hvor de kommer fra",
+	"Show the value of a setting":                                                         "Vis værdien af en indstilling",
+	"Change a setting in the config file":                                                 "Ændr en indstilling i konfigurationsfilen",
+	"Remove a setting from the config file":                                               "Fjern en indstilling fra konfigurationsfilen",
+	"Show where eepy keeps its files":                                                     "Vis hvor eepy gemmer sine filer",
+	"Answer yes to every confirmation prompt":                                             "Svar ja til alle bekræftelser",
+	"Same as --yes":                                                                       "Det samme som --yes",
+	"Never prompt; fail if confirmation is needed":                                        "Spørg aldrig; fejl, hvis der kræves bekræftelse",
+	"Set alarms with the alarm backend, ADB unless configured otherwise":                  "Sæt alarmer med alarmbackenden, ADB medmindre andet er konfigureret",
+	"Generate an HTML visualization of the plan":                                          "Lav en HTML-visualisering af planen",
+	"Do not open the HTML report":                                                         "Åbn ikke HTML-rapporten",
+	"Your target wake up time (HH:MM, 7am, ...)":                                          "Det tidspunkt, du gerne vil stå op (HH:MM, 7am, ...)",
+	"How long you want to sleep each night":                                               "Hvor længe du vil sove hver nat",
+	"The start date of the plan (YYYY-MM-DD, tomorrow, mon, +3d, ...)":                    "Planens startdato (YYYY-MM-DD, tomorrow, mon, +3d, ...)",
+	"Output format (text, calendar, timeline, json, yaml, csv or tsv)":                    "Outputformat (text, calendar, timeline, json, yaml, csv eller tsv)",
+	"Output format (text, json, yaml, csv or tsv)":                                        "Outputformat (text, json, yaml, csv eller tsv)",
+	"Output format (text, line, json, yaml, csv or tsv)":                                  "Outputformat (text, line, json, yaml, csv eller tsv)",
+	"Do not open the report after generating it":                                          "Åbn ikke rapporten, efter den er lavet",
+	"Where to set alarms: adb or termux (default: termux in Termux, adb elsewhere)":       "Hvor alarmer sættes: adb eller termux (standard: termux i Termux, ellers adb)",
+	"Serial or alias of the device to set alarms on; repeat it or pass 'all' for several": "Serienummer eller alias for den enhed, alarmer sættes på; gentag det eller angiv 'all' for flere",
+	"Do not skip setting an alarm for today":                                              "Spring ikke over at sætte en alarm for i dag",
+	"How many days ahead to keep alarms set (1-7)":                                        "Hvor mange dage frem der holdes alarmer sat (1-7)",
+	"Label template, with {day}, {time}, {number}, {days} and {target}":                   "Skabelon for etiketten, med {day}, {time}, {number}, {days} og {target}",
+	"Also set an alarm this long before each wake up alarm":                               "Sæt også en alarm så længe før hver vækkealarm",
+	"Also set an alarm this long after each wake up alarm":                                "Sæt også en alarm så længe efter hver vækkealarm",
+	"Set alarms that ring once instead of every week; only the next day's can be set":     "Sæt alarmer, der ringer én gang i stedet for hver uge; kun den næste dags kan sættes",
+	"Vibrate when the alarm rings":                                                        "Vibrér, når alarmen ringer",
+	"Ringtone URI, or silent (default: the clock app's)":                                  "URI for ringetone, eller silent (standard: urets app)",
+	"Set alarms without showing the clock app":                                            "Sæt alarmer uden at vise ur-appen",
+	"Also remind of each bedtime with a timer":                                            "Mind også om hver sengetid med en timer",
+	"Also remind this long before each bedtime, like 1h,30m":                              "Mind også om så længe før hver sengetid, f.eks. 1h,30m",
+	"Post a notification on the device about the alarms set, if the backend can":          "Vis en notifikation på enheden om de satte alarmer, hvis backenden kan",
+	"Only clear alarms on these devices (serial or alias)":                                "Fjern kun alarmer på disse enheder (serienummer eller alias)",
+	"Bar format (waybar, i3blocks or polybar)":                                            "Format til bjælken (waybar, i3blocks eller polybar)",
+	"How long before bedtime the bar turns to the soon class":                             "Hvor længe før sengetid bjælken skifter til klassen soon",
+	"Print an update at this interval instead of once":                                    "Skriv en opdatering med dette interval i stedet for én gang",
+	"Serial or alias of the device to switch; repeat it or pass 'all' for several":        "Serienummer eller alias for den enhed, der skal skiftes; gentag det eller angiv 'all' for flere",
+	"Do Not Disturb mode at night: priority, alarms, none or off":                         "Forstyr ikke-tilstand om natten: priority, alarms, none eller off",
+	"Turn the screen gray at night":                                                       "Gør skærmen grå om natten",
+	"Only restore these devices (serial or alias)":                                        "Gendan kun disse enheder (serienummer eller alias)",
+	"When you went to bed (HH:MM)":                                                        "Hvornår du gik i seng (HH:MM)",
+	"When you fell asleep (HH:MM)":                                                        "Hvornår du faldt i søvn (HH:MM)",
+	"A night awakening as HH:MM/DURATION, e.g. 03:10/20m (repeatable)":                    "En opvågning om natten som HH:MM/VARIGHED, f.eks. 03:10/20m (kan gentages)",
+	"When you woke up for the last time (HH:MM)":                                          "Hvornår du vågnede sidste gang (HH:MM)",
+	"When you got out of bed (HH:MM)":                                                     "Hvornår du stod ud af sengen (HH:MM)",
+	"How well you slept, from 1 (poor) to 5 (great), or 0 for none":                       "Hvor godt du sov, fra 1 (dårligt) til 5 (rigtig godt), eller 0 for ingen",
+	"A note about the night":                                                              "En note om natten",
+	"The morning the night ended on (YYYY-MM-DD, default: today)":                         "Den morgen, natten sluttede (YYYY-MM-DD, standard: i dag)",
+	"Move the entry to another morning (YYYY-MM-DD)":                                      "Flyt registreringen til en anden morgen (YYYY-MM-DD)",
+	"First morning to list (YYYY-MM-DD)":                                                  "Første morgen, der vises (YYYY-MM-DD)",
+	"Last morning to list (YYYY-MM-DD)":                                                   "Sidste morgen, der vises (YYYY-MM-DD)",
+	"Override the wake up time for the day (HH:MM)":                                       "Ændr tidspunktet for at stå op på dagen (HH:MM)",
+	"Override the bedtime for the day (HH:MM)":                                            "Ændr sengetiden for dagen (HH:MM)",
+	"Add a note to the day":                                                               "Tilføj en note til dagen",
+	"Insert a day that repeats this day's wake up time, moving later days back":           "Indsæt en dag, der gentager dagens tidspunkt for at stå op, og ryk senere dage tilbage",
+	"Remove the day, moving later days forward":                                           "Fjern dagen og ryk senere dage frem",
+	"Remove all overrides for the day":                                                    "Fjern alle ændringer for dagen",
+	"New initial wake up time (HH:MM)":                                                    "Nyt tidspunkt at stå op fra start (HH:MM)",
+	"New target wake up time (HH:MM)":                                                     "Nyt ønsket tidspunkt at stå op (HH:MM)",
+	"New adjustment per day":                                                              "Ny justering pr. dag",
+	"New sleep need per night":                                                            "Nyt søvnbehov pr. nat",
+	"First day to pause (YYYY-MM-DD, default: today)":                                     "Første dag på pause (YYYY-MM-DD, standard: i dag)",
+	"Day to resume the plan on (YYYY-MM-DD, default: today)":                              "Dag, planen fortsætter (YYYY-MM-DD, standard: i dag)",
+	"Set alarms for the restored plan":                                                    "Sæt alarmer for den gendannede plan",
+	"First morning to include (YYYY-MM-DD)":                                               "Første morgen, der tages med (YYYY-MM-DD)",
+	"Last morning to include (YYYY-MM-DD)":                                                "Sidste morgen, der tages med (YYYY-MM-DD)",
+	"How far from the plan a night may be and still count as on plan":                     "Hvor langt fra planen en nat må være og stadig tælle som efter planen",
+	"Target wake up time for new plans":                                                   "Ønsket tidspunkt at stå op for nye planer",
+	"Adjustment per day for new plans":                                                    "Justering pr. dag for nye planer",
+	"How long you want to sleep each night in new plans":                                  "Hvor længe du vil sove hver nat i nye planer",
+	"Set alarms when creating a plan with the older command line":                         "Sæt alarmer, når en plan oprettes med den ældre kommandolinje",
+	"Where alarms are set: adb or termux (default: termux in Termux, adb elsewhere)":      "Hvor alarmer sættes: adb eller termux (standard: termux i Termux, ellers adb)",
+	"Devices to set alarms on, as serials or aliases separated by commas, or all (default: the only one connected)":          "Enheder, der sættes alarmer på, som serienumre eller aliasser adskilt af kommaer, eller all (standard: den eneste tilsluttede)",
+	"Names for devices, like phone=R58M123,tablet=emulator-5554":                                                             "Navne til enheder, f.eks. phone=R58M123,tablet=emulator-5554",
+	"Also set an alarm for today when setting alarms":                                                                        "Sæt også en alarm for i dag, når alarmer sættes",
+	"How many days ahead alarms are kept set, up to 7":                                                                       "Hvor mange dage frem alarmer holdes sat, højst 7",
+	"Template of alarm labels, with {day}, {time}, {number}, {days} and {target} (default: Sleep Adjustment Wake Up: {day})": "Skabelon for alarmers etiketter, med {day}, {time}, {number}, {days} og {target} (standard: Sleep Adjustment Wake Up: {day})",
+	"Set alarms that ring once instead of every week on their weekday":                                                       "Sæt alarmer, der ringer én gang i stedet for hver uge på deres ugedag",
+	"Vibrate when an alarm rings":                                                                                            "Vibrér, når en alarm ringer",
+	"Ringtone URI of alarms, or silent (default: the clock app's)":                                                           "URI for alarmers ringetone, eller silent (standard: urets app)",
+	"Also remind of each bedtime with a timer when setting alarms":                                                           "Mind også om hver sengetid med en timer, når alarmer sættes",
+	"Do Not Disturb mode in bedtime mode: priority, alarms, none or off":                                                     "Forstyr ikke-tilstand i sengetidstilstand: priority, alarms, none eller off",
+	"Turn the screen gray in bedtime mode":                                                                                   "Gør skærmen grå i sengetidstilstand",
+	"Generate HTML reports without opening them":                                                                             "Lav HTML-rapporter uden at åbne dem",
+	"How far from the plan a night may be and still count as on plan in stats":                                               "Hvor langt fra planen en nat må være og stadig tælle som efter planen i stats",
+	"How long before bedtime the status bar turns to the soon class":                                                         "Hvor længe før sengetid statusbjælken skifter til klassen soon",
+	"Language and region of the output, like da_DK (default: from LC_ALL, LC_TIME or LANG)":                                  "Sprog og region for output, f.eks. da_DK (standard: fra LC_ALL, LC_TIME eller LANG)",
+	"12h or 24h (default: 24h, or the region's clock of the locale setting)":                                                 "12h eller 24h (standard: 24h, eller regionens fra indstillingen locale)",
+	"First day of the week, mon or sun (default: from the locale)":                                                           "Ugens første dag, mon eller sun (standard: fra sprogindstillingen)",
+	"Error: %v\n":                 "Fejl: %v\n",
+	"no Android device connected": "ingen Android-enhed tilsluttet",
+	"device unauthorized; accept the USB debugging prompt on the device":                       "enheden er ikke godkendt; accepter spørgsmålet om USB-fejlretning på enheden",
+	"device offline; reconnect it or restart adb":                                              "enheden er offline; tilslut den igen eller genstart adb",
+	"adb server refused %s: %s":                                                                "adb-serveren afviste %s: %s",
+	"%s: device is %s":                                                                         "%s: enheden er %s",
+	"cannot reach the adb server at %s; start it with 'adb start-server': %w":                  "kan ikke nå adb-serveren på %s; start den med 'adb start-server': %w",
+	"error reading adb reply to %s: %w":                                                        "fejl ved læsning af adb-svar på %s: %w",
+	"unexpected adb reply %q to %s":                                                            "uventet adb-svar %q på %s",
+	"invalid length %q: %w":                                                                    "ugyldig længde %q: %w",
+	"error reading device list: %w":                                                            "fejl ved læsning af enhedslisten: %w",
+	"error reading output of %q: %w":                                                           "fejl ved læsning af output fra %q: %w",
+	"error parsing device alias %q: want name=serial":                                          "fejl ved fortolkning af enhedsalias %q: skal være navn=serienummer",
+	"error parsing device aliases: all cannot be an alias":                                     "fejl ved fortolkning af enhedsaliasser: all kan ikke være et alias",
+	"%d devices are connected (%s); choose with --device, or --device all":                     "%d enheder er tilsluttet (%s); vælg med --device eller --device all",
+	"device %s is not connected: %w":                                                           "enheden %s er ikke tilsluttet: %w",
+	"unknown alarm backend %q; choose one of %s":                                               "ukendt alarmbackend %q; vælg en af %s",
+	"error loading config: %w":                                                                 "fejl ved indlæsning af konfigurationen: %w",
+	"invalid pre-alarm: %w":                                                                    "ugyldig pre-alarm: %w",
+	"invalid backup-alarm: %w":                                                                 "ugyldig backup-alarm: %w",
+	"invalid wind-down: %w":                                                                    "ugyldig wind-down: %w",
+	"%s is not between 0 and 2h":                                                               "%s er ikke mellem 0 og 2h",
+	"%s is not between 0 and %s":                                                               "%s er ikke mellem 0 og %s",
+	"unknown placeholder %s; use %s":                                                           "ukendt pladsholder %s; brug %s",
+	"the label needs {day} or {number} to tell the days apart":                                 "etiketten skal have {day} eller {number} for at skelne dagene",
+	"alarm days must be between 1 and %d, since alarms repeat weekly":                          "alarmdage skal være mellem 1 og %d, da alarmer gentages ugentligt",
+	"error reading alarms via %s: %w":                                                          "fejl ved læsning af alarmer via %s: %w",
+	"the alarms do not match the plan; run 'eepy alarms sync' to set them again":               "alarmerne passer ikke med planen; kør 'eepy alarms sync' for at sætte dem igen",
+	"error recording created alarms: %w":                                                       "fejl ved registrering af oprettede alarmer: %w",
+	"some alarms could not be set via %s":                                                      "nogle alarmer kunne ikke sættes via %s",
+	"a timer cannot ring at %s %s":                                                             "en timer kan ikke ringe %s %s",
+	"timers cannot be cancelled through the clock app: %w":                                     "timere kan ikke annulleres gennem ur-appen: %w",
+	"error reading created alarms: %w":                                                         "fejl ved læsning af oprettede alarmer: %w",
+	"error saving created alarms: %w":                                                          "fejl ved gemning af oprettede alarmer: %w",
+	"%d alarms could not be dismissed; run 'eepy alarms clear' to try again":                   "%d alarmer kunne ikke afvises; kør 'eepy alarms clear' for at prøve igen",
+	"unknown bar format %q":                                                                    "ukendt bjælkeformat %q",
+	"invalid watch interval %s":                                                                "ugyldigt interval for watch %s",
+	"unknown Do Not Disturb mode %q; choose one of %s":                                         "ukendt Forstyr ikke-tilstand %q; vælg en af %s",
+	"error reading bedtime mode: %w":                                                           "fejl ved læsning af sengetidstilstand: %w",
+	"error saving bedtime mode: %w":                                                            "fejl ved gemning af sengetidstilstand: %w",
+	"bedtime mode would change nothing; choose a dnd mode or grayscale":                        "sengetidstilstand ville ikke ændre noget; vælg en dnd-tilstand eller grayscale",
+	"bedtime mode could not be switched on %s; run 'eepy bedtime restore' to undo any changes": "sengetidstilstand kunne ikke skiftes på %s; kør 'eepy bedtime restore' for at fortryde ændringerne",
+	"the settings of %s could not be restored; connect it and try again":                       "indstillingerne på %s kunne ikke gendannes; tilslut den og prøv igen",
+	"unknown command %q":                                                                       "ukendt kommando %q",
+	"no active sleep plan found; create one with 'eepy plan new <wake-time>'":                  "ingen aktiv søvnplan fundet; opret en med 'eepy plan new <wake-time>'",
+	"error loading plan: %w":                                                                   "fejl ved indlæsning af planen: %w",
+	"error parsing start-date: %w":                                                             "fejl ved fortolkning af start-date: %w",
+	"error parsing wake-time: %w":                                                              "fejl ved fortolkning af wake-time: %w",
+	"error parsing target-wake-time: %w":                                                       "fejl ved fortolkning af target-wake-time: %w",
+	"error parsing adjustment: %w":                                                             "fejl ved fortolkning af adjustment: %w",
+	"error recording existing plan: %w":                                                        "fejl ved registrering af den eksisterende plan: %w",
+	"error archiving existing plan: %w":                                                        "fejl ved arkivering af den eksisterende plan: %w",
+	"error saving new plan: %w":                                                                "fejl ved gemning af den nye plan: %w",
+	"error recording plan revision: %w":                                                        "fejl ved registrering af planens udgave: %w",
+	"sleep need %s is outside %s to 16h":                                                       "søvnbehovet %s er uden for %s til 16h",
+	"plan new needs exactly one wake up time (HH:MM)":                                          "plan new skal have præcis ét tidspunkt at stå op (HH:MM)",
+	"invalid plan number %q":                                                                   "ugyldigt plannummer %q",
+	"no archived plan #%d":                                                                     "ingen arkiveret plan #%d",
+	"unknown setting %q; run 'eepy config list' to see them":                                   "ukendt indstilling %q; kør 'eepy config list' for at se dem",
+	"error parsing %s: %w":                                                                     "fejl ved fortolkning af %s: %w",
+	"invalid %s %q from %s: %w":                                                                "ugyldig %s %q fra %s: %w",
+	"config get needs exactly one setting":                                                     "config get skal have præcis én indstilling",
+	"config set needs a setting and a value":                                                   "config set skal have en indstilling og en værdi",
+	"invalid value for %s: %w":                                                                 "ugyldig værdi for %s: %w",
+	"error saving config: %w":                                                                  "fejl ved gemning af konfigurationen: %w",
+	"config unset needs exactly one setting":                                                   "config unset skal have præcis én indstilling",
+	"%s at %s is before %s at %s":                                                              "%s kl. %s er før %s kl. %s",
+	"quality must be between 1 and 5, or 0 for none, got %d":                                   "kvaliteten skal være mellem 1 og 5, eller 0 for ingen, fik %d",
+	"a final wake time is needed":                                                              "der skal være et tidspunkt for sidste opvågning",
+	"awakening at %s must last a while, got %s":                                                "opvågningen kl. %s skal vare et stykke tid, fik %s",
+	"awakening at %s for %s is not between falling asleep and the final wake":                  "opvågningen kl. %s i %s ligger ikke mellem indsovning og sidste opvågning",
+	"error reading diary line %d: %w":                                                          "fejl ved læsning af dagbogens linje %d: %w",
+	"no diary entry #%d":                                                                       "ingen dagbogsregistrering #%d",
+	"error parsing awake %q: expected HH:MM/DURATION":                                          "fejl ved fortolkning af awake %q: forventede HH:MM/VARIGHED",
+	"error parsing awake %q: %w":                                                               "fejl ved fortolkning af awake %q: %w",
+	"error parsing date: %w":                                                                   "fejl ved fortolkning af datoen: %w",
+	"error saving diary entry: %w":                                                             "fejl ved gemning af dagbogsregistreringen: %w",
+	"expected exactly one entry number":                                                        "forventede præcis ét registreringsnummer",
+	"invalid entry number %q":                                                                  "ugyldigt registreringsnummer %q",
+	"error parsing from: %w":                                                                   "fejl ved fortolkning af from: %w",
+	"error parsing to: %w":                                                                     "fejl ved fortolkning af to: %w",
+	"unexpected dumpsys alarm output: %s":                                                      "uventet output fra dumpsys alarm: %s",
+	"error parsing trigger time %q: %w":                                                        "fejl ved fortolkning af udløsningstiden %q: %w",
+	"plan edit needs exactly one date (YYYY-MM-DD)":                                            "plan edit skal have præcis én dato (YYYY-MM-DD)",
+	"%s is not part of the plan":                                                               "%s er ikke en del af planen",
+	"error parsing wake: %w":                                                                   "fejl ved fortolkning af wake: %w",
+	"error parsing bedtime: %w":                                                                "fejl ved fortolkning af bedtime: %w",
+	"cannot remove the only day of the plan":                                                   "kan ikke fjerne planens eneste dag",
+	"nothing to edit; see 'eepy help plan edit'":                                               "intet at ændre; se 'eepy help plan edit'",
+	"error saving plan: %w":                                                                    "fejl ved gemning af planen: %w",
+	"error parsing target: %w":                                                                 "fejl ved fortolkning af target: %w",
+	"invalid clock %q: use 12h or 24h":                                                         "ugyldigt ur %q: brug 12h eller 24h",
+	"invalid week start %q: use mon or sun":                                                    "ugyldig ugestart %q: brug mon eller sun",
+	"error parsing template: %w":                                                               "fejl ved fortolkning af skabelonen: %w",
+	"error creating temp file: %w":                                                             "fejl ved oprettelse af midlertidig fil: %w",
+	"error executing template: %w":                                                             "fejl ved udførelse af skabelonen: %w",
+	"error opening file with xdg-open: %w":                                                     "fejl ved åbning af filen med xdg-open: %w",
+	"error writing %s: %w":                                                                     "fejl ved skrivning af %s: %w",
+	"unknown output format %q":                                                                 "ukendt outputformat %q",
+	"invalid time %q: use HH:MM like 07:30, or a 12-hour time like 7:30am":                     "ugyldigt tidspunkt %q: brug HH:MM som 07:30, eller et 12-timers tidspunkt som 7:30am",
+	"invalid time %q: 12-hour times run from 12:00am to 11:59pm":                               "ugyldigt tidspunkt %q: 12-timers tidspunkter går fra 12:00am til 11:59pm",
+	"invalid date %q: use YYYY-MM-DD, today, tomorrow, a weekday like mon, or an offset like +3d": "ugyldig dato %q: brug YYYY-MM-DD, today, tomorrow, en ugedag som mon eller en forskydning som +3d",
+	"the plan is already paused":                   "planen er allerede på pause",
+	"the plan has already finished":                "planen er allerede afsluttet",
+	"the plan is not paused":                       "planen er ikke på pause",
+	"error parsing on: %w":                         "fejl ved fortolkning af on: %w",
+	"cannot resume before the pause started on %s": "kan ikke fortsætte før pausen, der startede %s",
+	"confirmation needed, but eepy is not running interactively; pass --yes to confirm": "bekræftelse kræves, men eepy kører ikke interaktivt; angiv --yes for at bekræfte",
+	"nothing to undo":                                                                       "intet at fortryde",
+	"nothing to redo":                                                                       "intet at gentage",
+	"error reading revision log: %w":                                                        "fejl ved læsning af udgavehistorikken: %w",
+	"error reading terminal settings: %w":                                                   "fejl ved læsning af terminalens indstillinger: %w",
+	"error switching terminal to raw mode: %w":                                              "fejl ved skift af terminalen til rå tilstand: %w",
+	"the alarms of the clock app cannot be read from Termux: %w":                            "ur-appens alarmer kan ikke læses fra Termux: %w",
+	"tui needs your current wake up time when there is no active plan":                      "tui skal have dit nuværende tidspunkt at stå op, når der ikke er nogen aktiv plan",
+	"error parsing sleep-need: %w":                                                          "fejl ved fortolkning af sleep-need: %w",
+	"tui takes at most one wake up time (HH:MM)":                                            "tui tager højst ét tidspunkt at stå op (HH:MM)",
+	"tui needs a terminal; use 'eepy plan new' in scripts":                                  "tui kræver en terminal; brug 'eepy plan new' i scripts",
+	"invalid device %q: set aliases with device-aliases":                                    "ugyldig enhed %q: sæt aliasser med device-aliases",
+	"invalid device %q: use a serial like R58M123, an alias or all":                         "ugyldig enhed %q: brug et serienummer som R58M123, et alias eller all",
+	"invalid ringtone %q: use silent or a URI like content://media/internal/audio/media/42": "ugyldig ringetone %q: brug silent eller en URI som content://media/internal/audio/media/42",
+	"unknown locale %q; use a locale for %s, like da_DK":                                    "ukendt sprogindstilling %q; brug en for %s, f.eks. da_DK",
+	"the clock app can only skip the next ring of a weekly alarm: %w":                       "urappen kan kun springe den næste ringning af en ugentlig alarm over: %w",
+	"Warning: error loading config: %v; using the built-in settings\n":                      "Advarsel: fejl ved indlæsning af konfigurationen: %v; bruger de indbyggede indstillinger\n",
+}
+
+// german is the German message catalogue, keyed by the English message.
+var german = map[string]string{
+	// Plan
+	"Your sleep calibration plan:":                                     "Dein Plan zur Schlafanpassung:",
+	"Ideal sleep: %.1f hours. Minimum functional sleep: %.1f hours.\n": "Idealer Schlaf: %.1f Stunden. Minimaler funktionaler Schlaf: %.1f Stunden.\n",
+	"Paused since %s. The dates below assume you resume tomorrow.\n":   "Pausiert seit %s. Die folgenden Daten gehen davon aus, dass du morgen fortfährst.\n",
+	"%s (Day %d)%s:\n":        "%s (Tag %d)%s:\n",
+	" [inserted]":             " [eingefügt]",
+	" (edited)":               " (bearbeitet)",
+	"  - Wake up at %s%s\n":   "  - Aufstehen um %s%s\n",
+	"  - Go to bed at %s%s\n": "  - Schlafen gehen um %s%s\n",
+	"  - Note: %s\n":          "  - Notiz: %s\n",
+	"You have reached your target sleep schedule!":                     "Du hast deinen Ziel-Schlafrhythmus erreicht!",
+	"An active sleep plan already exists. Do you want to override it?": "Es gibt bereits einen aktiven Schlafplan. Möchtest du ihn ersetzen?",
+	"(y/N):":               "(j/N):",
+	"y":                    "j",
+	"yes":                  "ja",
+	"Operation cancelled.": "Vorgang abgebrochen.",
+	"Schedule":             "Plan",
+	"History":              "Verlauf",
+	"Diary":                "Tagebuch",
+	"eepy plan editor":     "eepy-Planeditor",
+	"Current wake up":      "Aktuelles Aufwachen",
+	"Target wake up":       "Ziel-Aufwachzeit",
+	"Adjustment per day":   "Anpassung pro Tag",
+	"Start date":           "Startdatum",
+	"Sleep need":           "Schlafbedarf",
+	"↑↓ scroll  tab switch view  s save  q cancel":                                         "↑↓ blättern  Tab Ansicht wechseln  s speichern  q abbrechen",
+	"↑↓ select  ←→ change  PgUp/PgDn scroll  tab switch view  s save  q cancel":            "↑↓ wählen  ←→ ändern  Bild↑/Bild↓ blättern  Tab Ansicht wechseln  s speichern  q abbrechen",
+	"Saving replaces the active plan; 'eepy plan undo' brings it back.":                    "Speichern ersetzt den aktiven Plan; 'eepy plan undo' holt ihn zurück.",
+	"Saving replans the active plan and keeps its edits; 'eepy plan undo' brings it back.": "Speichern plant den aktiven Plan neu und behält seine Änderungen; 'eepy plan undo' holt ihn zurück.",
+	"The new start date drops the edits and pauses of the active plan. Save anyway?":       "Das neue Startdatum verwirft die Änderungen und Pausen des aktiven Plans. Trotzdem speichern?",
+	"No replaced plans yet.":                                                        "Noch keine ersetzten Pläne.",
+	"#%d %s: %s to %s over %d days (replaced %s)":                                   "#%d %s: %s bis %s über %d Tage (ersetzt %s)",
+	"No active sleep plan found. Create one by providing a wake-up time.":           "Kein aktiver Schlafplan gefunden. Erstelle einen, indem du eine Aufstehzeit angibst.",
+	"Plan paused from %s. Run 'eepy plan resume' to continue where you left off.\n": "Plan ab %s pausiert. Führe 'eepy plan resume' aus, um dort weiterzumachen, wo du aufgehört hast.\n",
+	"Undid %s from %s.\n":                                                           "%s vom %s rückgängig gemacht.\n",
+	"Redid %s from %s.\n":                                                           "%s vom %s wiederhergestellt.\n",
+	"create":                                                                        "Erstellung",
+	"edit":                                                                          "Bearbeitung",
+	"replan":                                                                        "Neuplanung",
+	"override":                                                                      "Ersetzung",
+	"pause":                                                                         "Pause",
+	"resume":                                                                        "Fortsetzung",
+
+	// Configuration
+	"Configuration directory: %s\n":             "Konfigurationsverzeichnis: %s\n",
+	"Config file:             %s\n":             "Konfigurationsdatei:       %s\n",
+	"Set %s to %s.\n":                           "%s auf %s gesetzt.\n",
+	"Unset %s.\n":                               "%s entfernt.\n",
+	"Note: %s is set and takes precedence.\n":   "Hinweis: %s ist gesetzt und hat Vorrang.\n",
+	"Active plan:             %s\n":             "Aktiver Plan:              %s\n",
+	"Replaced plans:          %s\n":             "Ersetzte Pläne:            %s\n",
+	"Revision log:            %s\n":             "Revisionsprotokoll:        %s\n",
+	"Sleep diary:             %s\n":             "Schlaftagebuch:            %s\n",
+	"Alarms set by eepy:      %s\n":             "Von eepy gestellte Wecker: %s\n",
+	"Settings before bedtime: %s\n":             "Einstellungen vor der Schlafenszeit: %s\n",
+	"%s is already in bedtime mode.\n":          "%s ist schon im Schlafenszeitmodus.\n",
+	"Error switching %s: %v\n":                  "Fehler beim Umschalten von %s: %v\n",
+	"Switched %s to bedtime mode.\n":            "%s ist in den Schlafenszeitmodus geschaltet.\n",
+	"Switched %s back from bedtime mode.\n":     "%s ist aus dem Schlafenszeitmodus zurückgeschaltet.\n",
+	"It is not bedtime; %s is left as it is.\n": "Es ist nicht Schlafenszeit; %s bleibt, wie es ist.\n",
+	"No device settings to restore.":            "Keine Geräteeinstellungen wiederherzustellen.",
+	"Restored the settings of %s.\n":            "Die Einstellungen von %s sind wiederhergestellt.\n",
+
+	// Alarms
+	"Setting alarms via %s...\n":                                 "Wecker werden über %s gestellt...\n",
+	"Skipping alarm for %s: the plan is paused.\n":               "Wecker für %s wird übersprungen: der Plan ist pausiert.\n",
+	"Setting alarm for %s: %s\n":                                 "Wecker für %s wird gestellt: %s\n",
+	"Sleep Adjustment Wake Up: %s":                               "Schlafanpassung, aufstehen: %s",
+	"%s (pre-alarm)":                                             "%s (Vorwecker)",
+	"%s (backup)":                                                "%s (Ersatzwecker)",
+	"%d alarms set, %d dismissed, %d failed":                     "%d Wecker gestellt, %d ausgeschaltet, %d fehlgeschlagen",
+	"Sleep plan alarms":                                          "Wecker des Schlafplans",
+	"Error posting notification: %v\n":                           "Fehler beim Anzeigen der Benachrichtigung: %v\n",
+	"Bedtime: %s":                                                "Schlafenszeit: %s",
+	"Bedtime in %s: %s":                                          "Schlafenszeit in %s: %s",
+	"Cancel %q on the device yourself: %v\n":                     "Brich %q selbst auf dem Gerät ab: %v\n",
+	"Reminder %q is already set.\n":                              "Die Erinnerung %q ist schon gestellt.\n",
+	"Setting reminder %q for %s\n":                               "Stelle die Erinnerung %q auf %s\n",
+	"Error setting reminder %q: %v\n":                            "Fehler beim Stellen der Erinnerung %q: %v\n",
+	"Reminder %q sent successfully.\n":                           "Die Erinnerung %q wurde gesendet.\n",
+	"Error setting alarm for %s: %v\n":                           "Fehler beim Stellen des Weckers für %s: %v\n",
+	"Alarm for %s sent successfully.\n":                          "Wecker für %s erfolgreich gesendet.\n",
+	"Summary:":                                                   "Zusammenfassung:",
+	"  %s: %d alarms set, %d dismissed, %d failed\n":             "  %s: %d Wecker gestellt, %d ausgeschaltet, %d fehlgeschlagen\n",
+	"Dismissed alarm %q.\n":                                      "Wecker %q ausgeschaltet.\n",
+	"Alarm for %s is already set.\n":                             "Der Wecker für %s ist schon gestellt.\n",
+	"  %s: not reachable: %v\n":                                  "  %s: nicht erreichbar: %v\n",
+	"No devices connected.":                                      "Keine Geräte verbunden.",
+	"Dismissed %d alarms via %s.\n":                              "%d Wecker über %s ausgeschaltet.\n",
+	"Error dismissing alarm %q: %v\n":                            "Fehler beim Ausschalten des Weckers %q: %v\n",
+	"Could not reach %s to dismiss its alarms: %v\n":             "%s ist nicht erreichbar, um die Wecker auszuschalten: %v\n",
+	"No alarms to clear.":                                        "Keine Wecker zu entfernen.",
+	"The plan has finished, so its alarms are no longer needed.": "Der Plan ist beendet, seine Wecker werden nicht mehr gebraucht.",
+	"Warning: %v\n":                                              "Warnung: %v\n",
+	"%s: the alarms match the plan.\n":                           "%s: die Wecker passen zum Plan.\n",
+	"  Missing:    %s\n":                                         "  Fehlt:      %s\n",
+	"  Mismatched: %s is set for %s\n":                           "  Abweichend: %s ist auf %s gestellt\n",
+	"  Extra:      %s\n":                                         "  Zusätzlich: %s\n",
+
+	// HTML report
+	"Generated HTML report: %s\n": "HTML-Bericht erstellt: %s\n",
+	"Error generating HTML: %v\n": "Fehler beim Erstellen des HTML-Berichts: %v\n",
+	"Your Sleep Calibration Plan": "Dein Plan zur Schlafanpassung",
+	"Days to Target":              "Tage bis zum Ziel",
+	"%d days":                     "%d Tage",
+	"Adjustment per Day":          "Anpassung pro Tag",
+	"Progress":                    "Fortschritt",
+	"Date":                        "Datum",
+	"Wake Up":                     "Aufstehen",
+	"Bedtime":                     "Schlafenszeit",
+	"Duration":                    "Dauer",
+	"Sleep Period":                "Schlafphase",
+	"(inserted)":                  "(eingefügt)",
+	"Edited":                      "Bearbeitet",
+	"Completed":                   "Erledigt",
+	"Remaining":                   "Verbleibend",
+	"Wake Up Time":                "Aufstehzeit",
+	"Sleep Duration (hours)":      "Schlafdauer (Stunden)",
+	"%.1f hours":                  "%.1f Stunden",
+
+	// Diary
+	"Logged entry #%d.\n":    "Eintrag #%d gespeichert.\n",
+	"Corrected entry #%d.\n": "Eintrag #%d korrigiert.\n",
+	"Deleted entry #%d.\n":   "Eintrag #%d gelöscht.\n",
+	"No diary entries found. Record one with 'eepy log add'.": "Keine Tagebucheinträge gefunden. Erstelle einen mit 'eepy log add'.",
+	" (Day %d)":                " (Tag %d)",
+	"In bed at":                "Im Bett um",
+	"Asleep at":                "Eingeschlafen um",
+	"Woke up at":               "Aufgewacht um",
+	"Out of bed at":            "Aufgestanden um",
+	"  - Awake at %s for %s\n": "  - Wach um %s für %s\n",
+	"  - Slept %.1f hours\n":   "  - %.1f Stunden geschlafen\n",
+	"  - Quality %d/5\n":       "  - Qualität %d/5\n",
+	"Your sleep statistics:":   "Deine Schlafstatistik:",
+	"No nights logged. Record one with 'eepy log add'.":                                   "Keine Nächte erfasst. Erfasse eine mit 'eepy log add'.",
+	"Nights logged: %d (%d on the plan)\n":                                                "Erfasste Nächte: %d (%d im Plan)\n",
+	"Mean wake up deviation: %+.0f minutes\n":                                             "Mittlere Abweichung beim Aufwachen: %+.0f Minuten\n",
+	"Mean bedtime deviation: %+.0f minutes\n":                                             "Mittlere Abweichung bei der Schlafenszeit: %+.0f Minuten\n",
+	"Nights within %d minutes of the plan: %.0f%%\n":                                      "Nächte innerhalb von %d Minuten des Plans: %.0f%%\n",
+	"Average sleep: %.1f hours (minimum functional sleep: %.1f hours, %d nights below)\n": "Durchschnittlicher Schlaf: %.1f Stunden (minimaler funktionaler Schlaf: %.1f Stunden, %d Nächte darunter)\n",
+	"Sleep debt: %.1f hours\n":                                                            "Schlafschuld: %.1f Stunden\n",
+	"Sleep Regularity Index: %.0f\n":                                                      "Schlafregelmäßigkeitsindex: %.0f\n",
+	"Social jet lag: %.0f minutes\n":                                                      "Sozialer Jetlag: %.0f Minuten\n",
+
+	// Status
+	"Your plan has not started yet.":                          "Dein Plan hat noch nicht begonnen.",
+	"Your plan is paused. Resume it with 'eepy plan resume'.": "Dein Plan ist pausiert. Setze ihn mit 'eepy plan resume' fort.",
+	"Your plan is complete. Keep to your target schedule.":    "Dein Plan ist abgeschlossen. Halte deinen Ziel-Schlafrhythmus bei.",
+	"Day %d of %d.\n":                          "Tag %d von %d.\n",
+	"Progress toward %s":                       "Fortschritt zu %s",
+	"Wake up time today: %s\n":                 "Aufstehen heute: %s\n",
+	"Bedtime: %s, %s. You should be asleep.\n": "Schlafenszeit: %s, %s. Du solltest schlafen.\n",
+	"Bedtime: %s, %s\n":                        "Schlafenszeit: %s, %s\n",
+	"Next wake up: %s\n":                       "Nächstes Aufstehen: %s\n",
+	"%s ago":                                   "vor %s",
+	"in %s":                                    "in %s",
+	"paused":                                   "pausiert",
+	"starts soon, bed %s %s":                   "beginnt bald, Bett %s %s",
+	"done":                                     "fertig",
+	"day %d/%d":                                "Tag %d/%d",
+	"%s, bed %s %s, wake %s":                   "%s, Bett %s %s, auf %s",
+	"eepy paused":                              "eepy pausiert",
+	"eepy: no plan":                            "eepy: kein Plan",
+	"bed %s %s":                                "Bett %s %s",
+
+	// Help and errors
+	"Usage: %s <command>\n\n": "Aufruf: %s <Befehl>\n\n",
+	"Usage: %s %s\n\n":        "Aufruf: %s %s\n\n",
+	"Usage: %s [flags]\n\n":   "Aufruf: %s [Optionen]\n\n",
+	"\nCommands:":             "\nBefehle:",
+	"\nRun '%s help <command>' for more about a command.\n":                      "\nFühre '%s help <Befehl>' aus, um mehr über einen Befehl zu erfahren.\n",
+	"\nThe older form 'eepy [wake-time] [flags]' still creates or shows a plan.": "\nDie ältere Form 'eepy [wake-time] [Optionen]' erstellt oder zeigt weiterhin einen Plan.",
+	"\nFlags:\n%s":                                                                        "\nOptionen:\n%s",
+	"Calibrate your sleep schedule":                                                       "Passe deinen Schlafrhythmus an",
+	"Create, show and change your sleep plan":                                             "Erstelle, zeige und ändere deinen Schlafplan",
+	"Create a plan from your current wake up time":                                        "Erstelle einen Plan ausgehend von deiner jetzigen Aufstehzeit",
+	"Show the active plan":                                                                "Zeige den aktiven Plan",
+	"Override a single day of the plan":                                                   "Ändere einen einzelnen Tag des Plans",
+	"Regenerate the plan from new parameters, keeping edits":                              "Erstelle den Plan mit neuen Parametern neu und behalte die Änderungen",
+	"Put the plan on hold":                                                                "Pausiere den Plan",
+	"Continue a paused plan":                                                              "Setze einen pausierten Plan fort",
+	"Restore the previous revision of the plan":                                           "Stelle die vorherige Fassung des Plans wieder her",
+	"Reapply a revision that was undone":                                                  "Wende eine rückgängig gemachte Fassung erneut an",
+	"Manage wake up alarms":                                                               "Verwalte Weckalarme",
+	"Set alarms for the active plan":                                                      "Stelle Alarme für den aktiven Plan",
+	"Check that the alarms on the device match the plan":                                  "Prüfe, ob die Alarme auf dem Gerät zum Plan passen",
+	"Dismiss the alarms eepy has set":                                                     "Verwirf die Alarme, die eepy gestellt hat",
+	"List the devices alarms can be set on":                                               "Zeige die Geräte, auf denen Alarme gestellt werden können",
+	"Switch devices to Do Not Disturb and grayscale at night":                             "Schalte Geräte nachts auf Nicht stören und Graustufen",
+	"Switch devices into or out of bedtime mode for the time of night":                    "Schalte Geräte je nach Uhrzeit in den Schlafenszeitmodus oder heraus",
+	"Restore the settings bedtime mode changed":                                           "Stelle die Einstellungen wieder her, die der Schlafenszeitmodus geändert hat",
+	"Generate reports about the plan":                                                     "Erstelle Berichte über den Plan",
+	"Generate and open an HTML visualization of the plan":                                 "Erstelle und öffne eine HTML-Darstellung des Plans",
+	"Keep a diary of how you slept":                                                       "Führe ein Tagebuch darüber, wie du geschlafen hast",
+	"Record a night":                                                                      "Trage eine Nacht ein",
+	"List recorded nights":                                                                "Zeige eingetragene Nächte",
+	"Correct a recorded night":                                                            "Korrigiere eine eingetragene Nacht",
+	"Delete a recorded night":                                                             "Lösche eine eingetragene Nacht",
+	"Show today's wake up time, tonight's bedtime and how long until it":                  "Zeige die heutige Aufstehzeit, die heutige Schlafenszeit und wie lange es noch dauert",
+	"Print the bedtime countdown for waybar, i3blocks or polybar":                         "Gib den Countdown bis zur Schlafenszeit für waybar, i3blocks oder polybar aus",
+	"Try out plans in a full-screen editor":                                               "Probiere Pläne in einem Vollbildeditor aus",
+	"Compare your diary with the plan":                                                    "Vergleiche dein Tagebuch mit dem Plan",
+	"List replaced plans or show one of them":                                             "Zeige ersetzte Pläne oder einen davon",
+	"Inspect and change eepy's configuration":                                             "Zeige und ändere die Konfiguration von eepy",
+	"List the settings, their values and where they come from":                            "Zeige die Einstellungen, ihre Werte und woher sie kommen",
+	"Show the value of a setting":                                                         "Zeige den Wert einer Einstellung",
+	"Change a setting in the config file":                                                 "Ändere eine Einstellung in der Konfigurationsdatei",
+	"Remove a setting from the config file":                                               "Entferne eine Einstellung aus der Konfigurationsdatei",
+	"Show where eepy keeps its files":                                                     "Zeige, wo eepy seine Dateien ablegt",
+	"Answer yes to every confirmation prompt":                                             "Beantworte jede Rückfrage mit Ja",
+	"Same as --yes":                                                                       "Dasselbe wie --yes",
+	"Never prompt; fail if confirmation is needed":                                        "Nie nachfragen; schlägt fehl, wenn eine Bestätigung nötig ist",
+	"Set alarms with the alarm backend, ADB unless configured otherwise":                  "Stelle Alarme über das Alarm-Backend, ADB, sofern nicht anders konfiguriert",
+	"Generate an HTML visualization of the plan":                                          "Erstelle eine HTML-Darstellung des Plans",
+	"Do not open the HTML report":                                                         "Öffne den HTML-Bericht nicht",
+	"Your target wake up time (HH:MM, 7am, ...)":                                          "Deine gewünschte Aufstehzeit (HH:MM, 7am, ...)",
+	"How long you want to sleep each night":                                               "Wie lange du jede Nacht schlafen möchtest",
+	"The start date of the plan (YYYY-MM-DD, tomorrow, mon, +3d, ...)":                    "Das Startdatum des Plans (YYYY-MM-DD, tomorrow, mon, +3d, ...)",
+	"Output format (text, calendar, timeline, json, yaml, csv or tsv)":                    "Ausgabeformat (text, calendar, timeline, json, yaml, csv oder tsv)",
+	"Output format (text, json, yaml, csv or tsv)":                                        "Ausgabeformat (text, json, yaml, csv oder tsv)",
+	"Output format (text, line, json, yaml, csv or tsv)":                                  "Ausgabeformat (text, line, json, yaml, csv oder tsv)",
+	"Do not open the report after generating it":                                          "Öffne den Bericht nach dem Erstellen nicht",
+	"Where to set alarms: adb or termux (default: termux in Termux, adb elsewhere)":       "Wo Alarme gestellt werden: adb oder termux (Standard: termux in Termux, sonst adb)",
+	"Serial or alias of the device to set alarms on; repeat it or pass 'all' for several": "Seriennummer oder Alias des Geräts für die Alarme; wiederhole es oder gib 'all' für mehrere an",
+	"Do not skip setting an alarm for today":                                              "Auch für heute einen Alarm stellen",
+	"How many days ahead to keep alarms set (1-7)":                                        "Für wie viele Tage im Voraus Alarme gestellt bleiben (1-7)",
+	"Label template, with {day}, {time}, {number}, {days} and {target}":                   "Vorlage für die Bezeichnung, mit {day}, {time}, {number}, {days} und {target}",
+	"Also set an alarm this long before each wake up alarm":                               "Stelle auch einen Alarm so lange vor jedem Weckalarm",
+	"Also set an alarm this long after each wake up alarm":                                "Stelle auch einen Alarm so lange nach jedem Weckalarm",
+	"Set alarms that ring once instead of every week; only the next day's can be set":     "Stelle Alarme, die einmal statt jede Woche klingeln; nur der des nächsten Tages kann gestellt werden",
+	"Vibrate when the alarm rings":                                                        "Vibrieren, wenn der Alarm klingelt",
+	"Ringtone URI, or silent (default: the clock app's)":                                  "URI des Klingeltons oder silent (Standard: der der Uhr-App)",
+	"Set alarms without showing the clock app":                                            "Stelle Alarme, ohne die Uhr-App zu zeigen",
+	"Also remind of each bedtime with a timer":                                            "Erinnere auch mit einem Timer an jede Schlafenszeit",
+	"Also remind this long before each bedtime, like 1h,30m":                              "Erinnere auch so lange vor jeder Schlafenszeit, etwa 1h,30m",
+	"Post a notification on the device about the alarms set, if the backend can":          "Zeige auf dem Gerät eine Benachrichtigung über die gestellten Alarme, falls das Backend es kann",
+	"Only clear alarms on these devices (serial or alias)":                                "Entferne nur Alarme auf diesen Geräten (Seriennummer oder Alias)",
+	"Bar format (waybar, i3blocks or polybar)":                                            "Format der Leiste (waybar, i3blocks oder polybar)",
+	"How long before bedtime the bar turns to the soon class":                             "Wie lange vor der Schlafenszeit die Leiste zur Klasse soon wechselt",
+	"Print an update at this interval instead of once":                                    "Gib in diesem Abstand eine Aktualisierung aus statt einmal",
+	"Serial or alias of the device to switch; repeat it or pass 'all' for several":        "Seriennummer oder Alias des umzuschaltenden Geräts; wiederhole es oder gib 'all' für mehrere an",
+	"Do Not Disturb mode at night: priority, alarms, none or off":                         "Nicht-stören-Modus in der Nacht: priority, alarms, none oder off",
+	"Turn the screen gray at night":                                                       "Schalte den Bildschirm nachts grau",
+	"Only restore these devices (serial or alias)":                                        "Stelle nur diese Geräte wieder her (Seriennummer oder Alias)",
+	"When you went to bed (HH:MM)":                                                        "Wann du ins Bett gegangen bist (HH:MM)",
+	"When you fell asleep (HH:MM)":                                                        "Wann du eingeschlafen bist (HH:MM)",
+	"A night awakening as HH:MM/DURATION, e.g. 03:10/20m (repeatable)":                    "Ein nächtliches Aufwachen als HH:MM/DAUER, z. B. 03:10/20m (wiederholbar)",
+	"When you woke up for the last time (HH:MM)":                                          "Wann du zuletzt aufgewacht bist (HH:MM)",
+	"When you got out of bed (HH:MM)":                                                     "Wann du aufgestanden bist (HH:MM)",
+	"How well you slept, from 1 (poor) to 5 (great), or 0 for none":                       "Wie gut du geschlafen hast, von 1 (schlecht) bis 5 (sehr gut), oder 0 für keine Angabe",
+	"A note about the night":                                                              "Eine Notiz zur Nacht",
+	"The morning the night ended on (YYYY-MM-DD, default: today)":                         "Der Morgen, an dem die Nacht endete (YYYY-MM-DD, Standard: heute)",
+	"Move the entry to another morning (YYYY-MM-DD)":                                      "Verschiebe den Eintrag auf einen anderen Morgen (YYYY-MM-DD)",
+	"First morning to list (YYYY-MM-DD)":                                                  "Erster anzuzeigender Morgen (YYYY-MM-DD)",
+	"Last morning to list (YYYY-MM-DD)":                                                   "Letzter anzuzeigender Morgen (YYYY-MM-DD)",
+	"Override the wake up time for the day (HH:MM)":                                       "Ändere die Aufstehzeit des Tages (HH:MM)",
+	"Override the bedtime for the day (HH:MM)":                                            "Ändere die Schlafenszeit des Tages (HH:MM)",
+	"Add a note to the day":                                                               "Füge dem Tag eine Notiz hinzu",
+	"Insert a day that repeats this day's wake up time, moving later days back":           "Füge einen Tag ein, der die Aufstehzeit dieses Tages wiederholt, und verschiebe spätere Tage nach hinten",
+	"Remove the day, moving later days forward":                                           "Entferne den Tag und ziehe spätere Tage vor",
+	"Remove all overrides for the day":                                                    "Entferne alle Änderungen des Tages",
+	"New initial wake up time (HH:MM)":                                                    "Neue anfängliche Aufstehzeit (HH:MM)",
+	"New target wake up time (HH:MM)":                                                     "Neue gewünschte Aufstehzeit (HH:MM)",
+	"New adjustment per day":                                                              "Neue Anpassung pro Tag",
+	"New sleep need per night":                                                            "Neuer Schlafbedarf pro Nacht",
+	"First day to pause (YYYY-MM-DD, default: today)":                                     "Erster pausierter Tag (YYYY-MM-DD, Standard: heute)",
+	"Day to resume the plan on (YYYY-MM-DD, default: today)":                              "Tag, an dem der Plan fortgesetzt wird (YYYY-MM-DD, Standard: heute)",
+	"Set alarms for the restored plan":                                                    "Stelle Alarme für den wiederhergestellten Plan",
+	"First morning to include (YYYY-MM-DD)":                                               "Erster einbezogener Morgen (YYYY-MM-DD)",
+	"Last morning to include (YYYY-MM-DD)":                                                "Letzter einbezogener Morgen (YYYY-MM-DD)",
+	"How far from the plan a night may be and still count as on plan":                     "Wie weit eine Nacht vom Plan abweichen darf und trotzdem als planmäßig zählt",
+	"Target wake up time for new plans":                                                   "Gewünschte Aufstehzeit für neue Pläne",
+	"Adjustment per day for new plans":                                                    "Anpassung pro Tag für neue Pläne",
+	"How long you want to sleep each night in new plans":                                  "Wie lange du in neuen Plänen jede Nacht schlafen möchtest",
+	"Set alarms when creating a plan with the older command line":                         "Stelle Alarme, wenn ein Plan über die ältere Kommandozeile erstellt wird",
+	"Where alarms are set: adb or termux (default: termux in Termux, adb elsewhere)":      "Wo Alarme gestellt werden: adb oder termux (Standard: termux in Termux, sonst adb)",
+	"Devices to set alarms on, as serials or aliases separated by commas, or all (default: the only one connected)":          "Geräte für die Alarme, als durch Kommas getrennte Seriennummern oder Aliasse, oder all (Standard: das einzige verbundene)",
+	"Names for devices, like phone=R58M123,tablet=emulator-5554":                                                             "Namen für Geräte, etwa phone=R58M123,tablet=emulator-5554",
+	"Also set an alarm for today when setting alarms":                                                                        "Stelle beim Stellen von Alarmen auch einen für heute",
+	"How many days ahead alarms are kept set, up to 7":                                                                       "Für wie viele Tage im Voraus Alarme gestellt bleiben, höchstens 7",
+	"Template of alarm labels, with {day}, {time}, {number}, {days} and {target} (default: Sleep Adjustment Wake Up: {day})": "Vorlage für die Bezeichnung der Alarme, mit {day}, {time}, {number}, {days} und {target} (Standard: Sleep Adjustment Wake Up: {day})",
+	"Set alarms that ring once instead of every week on their weekday":                                                       "Stelle Alarme, die einmal statt jede Woche an ihrem Wochentag klingeln",
+	"Vibrate when an alarm rings":                                                                                            "Vibrieren, wenn ein Alarm klingelt",
+	"Ringtone URI of alarms, or silent (default: the clock app's)":                                                           "URI des Klingeltons der Alarme oder silent (Standard: der der Uhr-App)",
+	"Also remind of each bedtime with a timer when setting alarms":                                                           "Erinnere beim Stellen von Alarmen auch mit einem Timer an jede Schlafenszeit",
+	"Do Not Disturb mode in bedtime mode: priority, alarms, none or off":                                                     "Nicht-stören-Modus im Schlafenszeitmodus: priority, alarms, none oder off",
+	"Turn the screen gray in bedtime mode":                                                                                   "Schalte den Bildschirm im Schlafenszeitmodus grau",
+	"Generate HTML reports without opening them":                                                                             "Erstelle HTML-Berichte, ohne sie zu öffnen",
+	"How far from the plan a night may be and still count as on plan in stats":                                               "Wie weit eine Nacht in stats vom Plan abweichen darf und trotzdem als planmäßig zählt",
+	"How long before bedtime the status bar turns to the soon class":                                                         "Wie lange vor der Schlafenszeit die Statusleiste zur Klasse soon wechselt",
+	"Language and region of the output, like da_DK (default: from LC_ALL, LC_TIME or LANG)":                                  "Sprache und Region der Ausgabe, etwa da_DK (Standard: aus LC_ALL, LC_TIME oder LANG)",
+	"12h or 24h (default: 24h, or the region's clock of the locale setting)":                                                 "12h oder 24h (Standard: 24h, oder die der Region aus der Einstellung locale)",
+	"First day of the week, mon or sun (default: from the locale)":                                                           "Erster Tag der Woche, mon oder sun (Standard: aus dem Gebietsschema)",
+	"Error: %v\n":                 "Fehler: %v\n",
+	"no Android device connected": "kein Android-Gerät verbunden",
+	"device unauthorized; accept the USB debugging prompt on the device":                       "Gerät nicht autorisiert; bestätige die Abfrage zum USB-Debugging auf dem Gerät",
+	"device offline; reconnect it or restart adb":                                              "Gerät offline; verbinde es erneut oder starte adb neu",
+	"adb server refused %s: %s":                                                                "der adb-Server hat %s abgelehnt: %s",
+	"%s: device is %s":                                                                         "%s: Gerät ist %s",
+	"cannot reach the adb server at %s; start it with 'adb start-server': %w":                  "der adb-Server unter %s ist nicht erreichbar; starte ihn mit 'adb start-server': %w",
+	"error reading adb reply to %s: %w":                                                        "Fehler beim Lesen der adb-Antwort auf %s: %w",
+	"unexpected adb reply %q to %s":                                                            "unerwartete adb-Antwort %q auf %s",
+	"invalid length %q: %w":                                                                    "ungültige Länge %q: %w",
+	"error reading device list: %w":                                                            "Fehler beim Lesen der Geräteliste: %w",
+	"error reading output of %q: %w":                                                           "Fehler beim Lesen der Ausgabe von %q: %w",
+	"error parsing device alias %q: want name=serial":                                          "Fehler beim Lesen des Gerätealias %q: erwartet name=Seriennummer",
+	"error parsing device aliases: all cannot be an alias":                                     "Fehler beim Lesen der Gerätealiasse: all kann kein Alias sein",
+	"%d devices are connected (%s); choose with --device, or --device all":                     "%d Geräte sind verbunden (%s); wähle mit --device oder --device all",
+	"device %s is not connected: %w":                                                           "Gerät %s ist nicht verbunden: %w",
+	"unknown alarm backend %q; choose one of %s":                                               "unbekanntes Alarm-Backend %q; wähle eines von %s",
+	"error loading config: %w":                                                                 "Fehler beim Laden der Konfiguration: %w",
+	"invalid pre-alarm: %w":                                                                    "ungültiger pre-alarm: %w",
+	"invalid backup-alarm: %w":                                                                 "ungültiger backup-alarm: %w",
+	"invalid wind-down: %w":                                                                    "ungültiges wind-down: %w",
+	"%s is not between 0 and 2h":                                                               "%s liegt nicht zwischen 0 und 2h",
+	"%s is not between 0 and %s":                                                               "%s liegt nicht zwischen 0 und %s",
+	"unknown placeholder %s; use %s":                                                           "unbekannter Platzhalter %s; verwende %s",
+	"the label needs {day} or {number} to tell the days apart":                                 "die Bezeichnung braucht {day} oder {number}, um die Tage zu unterscheiden",
+	"alarm days must be between 1 and %d, since alarms repeat weekly":                          "Alarmtage müssen zwischen 1 und %d liegen, da sich Alarme wöchentlich wiederholen",
+	"error reading alarms via %s: %w":                                                          "Fehler beim Lesen der Alarme über %s: %w",
+	"the alarms do not match the plan; run 'eepy alarms sync' to set them again":               "die Alarme passen nicht zum Plan; führe 'eepy alarms sync' aus, um sie neu zu stellen",
+	"error recording created alarms: %w":                                                       "Fehler beim Festhalten der gestellten Alarme: %w",
+	"some alarms could not be set via %s":                                                      "einige Alarme konnten nicht über %s gestellt werden",
+	"a timer cannot ring at %s %s":                                                             "ein Timer kann nicht am %s um %s klingeln",
+	"timers cannot be cancelled through the clock app: %w":                                     "Timer können nicht über die Uhr-App abgebrochen werden: %w",
+	"error reading created alarms: %w":                                                         "Fehler beim Lesen der gestellten Alarme: %w",
+	"error saving created alarms: %w":                                                          "Fehler beim Speichern der gestellten Alarme: %w",
+	"%d alarms could not be dismissed; run 'eepy alarms clear' to try again":                   "%d Alarme konnten nicht verworfen werden; führe 'eepy alarms clear' aus, um es erneut zu versuchen",
+	"unknown bar format %q":                                                                    "unbekanntes Leistenformat %q",
+	"invalid watch interval %s":                                                                "ungültiges watch-Intervall %s",
+	"unknown Do Not Disturb mode %q; choose one of %s":                                         "unbekannter Nicht-stören-Modus %q; wähle einen von %s",
+	"error reading bedtime mode: %w":                                                           "Fehler beim Lesen des Schlafenszeitmodus: %w",
+	"error saving bedtime mode: %w":                                                            "Fehler beim Speichern des Schlafenszeitmodus: %w",
+	"bedtime mode would change nothing; choose a dnd mode or grayscale":                        "der Schlafenszeitmodus würde nichts ändern; wähle einen dnd-Modus oder grayscale",
+	"bedtime mode could not be switched on %s; run 'eepy bedtime restore' to undo any changes": "der Schlafenszeitmodus konnte auf %s nicht umgeschaltet werden; führe 'eepy bedtime restore' aus, um Änderungen rückgängig zu machen",
+	"the settings of %s could not be restored; connect it and try again":                       "die Einstellungen von %s konnten nicht wiederhergestellt werden; verbinde es und versuche es erneut",
+	"unknown command %q":                                                                       "unbekannter Befehl %q",
+	"no active sleep plan found; create one with 'eepy plan new <wake-time>'":                  "kein aktiver Schlafplan gefunden; erstelle einen mit 'eepy plan new <wake-time>'",
+	"error loading plan: %w":                                                                   "Fehler beim Laden des Plans: %w",
+	"error parsing start-date: %w":                                                             "Fehler beim Lesen von start-date: %w",
+	"error parsing wake-time: %w":                                                              "Fehler beim Lesen von wake-time: %w",
+	"error parsing target-wake-time: %w":                                                       "Fehler beim Lesen von target-wake-time: %w",
+	"error parsing adjustment: %w":                                                             "Fehler beim Lesen von adjustment: %w",
+	"error recording existing plan: %w":                                                        "Fehler beim Festhalten des bestehenden Plans: %w",
+	"error archiving existing plan: %w":                                                        "Fehler beim Archivieren des bestehenden Plans: %w",
+	"error saving new plan: %w":                                                                "Fehler beim Speichern des neuen Plans: %w",
+	"error recording plan revision: %w":                                                        "Fehler beim Festhalten der Planfassung: %w",
+	"sleep need %s is outside %s to 16h":                                                       "Schlafbedarf %s liegt außerhalb von %s bis 16h",
+	"plan new needs exactly one wake up time (HH:MM)":                                          "plan new braucht genau eine Aufstehzeit (HH:MM)",
+	"invalid plan number %q":                                                                   "ungültige Plannummer %q",
+	"no archived plan #%d":                                                                     "kein archivierter Plan #%d",
+	"unknown setting %q; run 'eepy config list' to see them":                                   "unbekannte Einstellung %q; führe 'eepy config list' aus, um sie zu sehen",
+	"error parsing %s: %w":                                                                     "Fehler beim Lesen von %s: %w",
+	"invalid %s %q from %s: %w":                                                                "ungültiges %s %q aus %s: %w",
+	"config get needs exactly one setting":                                                     "config get braucht genau eine Einstellung",
+	"config set needs a setting and a value":                                                   "config set braucht eine Einstellung und einen Wert",
+	"invalid value for %s: %w":                                                                 "ungültiger Wert für %s: %w",
+	"error saving config: %w":                                                                  "Fehler beim Speichern der Konfiguration: %w",
+	"config unset needs exactly one setting":                                                   "config unset braucht genau eine Einstellung",
+	"%s at %s is before %s at %s":                                                              "%s um %s liegt vor %s um %s",
+	"quality must be between 1 and 5, or 0 for none, got %d":                                   "die Qualität muss zwischen 1 und 5 liegen, oder 0 für keine Angabe, erhalten: %d",
+	"a final wake time is needed":                                                              "eine Zeit für das letzte Aufwachen ist nötig",
+	"awakening at %s must last a while, got %s":                                                "das Aufwachen um %s muss eine Weile dauern, erhalten: %s",
+	"awakening at %s for %s is not between falling asleep and the final wake":                  "das Aufwachen um %s für %s liegt nicht zwischen Einschlafen und letztem Aufwachen",
+	"error reading diary line %d: %w":                                                          "Fehler beim Lesen von Zeile %d des Tagebuchs: %w",
+	"no diary entry #%d":                                                                       "kein Tagebucheintrag #%d",
+	"error parsing awake %q: expected HH:MM/DURATION":                                          "Fehler beim Lesen von awake %q: erwartet HH:MM/DAUER",
+	"error parsing awake %q: %w":                                                               "Fehler beim Lesen von awake %q: %w",
+	"error parsing date: %w":                                                                   "Fehler beim Lesen des Datums: %w",
+	"error saving diary entry: %w":                                                             "Fehler beim Speichern des Tagebucheintrags: %w",
+	"expected exactly one entry number":                                                        "genau eine Eintragsnummer erwartet",
+	"invalid entry number %q":                                                                  "ungültige Eintragsnummer %q",
+	"error parsing from: %w":                                                                   "Fehler beim Lesen von from: %w",
+	"error parsing to: %w":                                                                     "Fehler beim Lesen von to: %w",
+	"unexpected dumpsys alarm output: %s":                                                      "unerwartete Ausgabe von dumpsys alarm: %s",
+	"error parsing trigger time %q: %w":                                                        "Fehler beim Lesen der Auslösezeit %q: %w",
+	"plan edit needs exactly one date (YYYY-MM-DD)":                                            "plan edit braucht genau ein Datum (YYYY-MM-DD)",
+	"%s is not part of the plan":                                                               "%s gehört nicht zum Plan",
+	"error parsing wake: %w":                                                                   "Fehler beim Lesen von wake: %w",
+	"error parsing bedtime: %w":                                                                "Fehler beim Lesen von bedtime: %w",
+	"cannot remove the only day of the plan":                                                   "der einzige Tag des Plans kann nicht entfernt werden",
+	"nothing to edit; see 'eepy help plan edit'":                                               "nichts zu ändern; siehe 'eepy help plan edit'",
+	"error saving plan: %w":                                                                    "Fehler beim Speichern des Plans: %w",
+	"error parsing target: %w":                                                                 "Fehler beim Lesen von target: %w",
+	"invalid clock %q: use 12h or 24h":                                                         "ungültige Uhr %q: verwende 12h oder 24h",
+	"invalid week start %q: use mon or sun":                                                    "ungültiger Wochenbeginn %q: verwende mon oder sun",
+	"error parsing template: %w":                                                               "Fehler beim Lesen der Vorlage: %w",
+	"error creating temp file: %w":                                                             "Fehler beim Anlegen der temporären Datei: %w",
+	"error executing template: %w":                                                             "Fehler beim Ausführen der Vorlage: %w",
+	"error opening file with xdg-open: %w":                                                     "Fehler beim Öffnen der Datei mit xdg-open: %w",
+	"error writing %s: %w":                                                                     "Fehler beim Schreiben von %s: %w",
+	"unknown output format %q":                                                                 "unbekanntes Ausgabeformat %q",
+	"invalid time %q: use HH:MM like 07:30, or a 12-hour time like 7:30am":                     "ungültige Zeit %q: verwende HH:MM wie 07:30 oder eine 12-Stunden-Zeit wie 7:30am",
+	"invalid time %q: 12-hour times run from 12:00am to 11:59pm":                               "ungültige Zeit %q: 12-Stunden-Zeiten reichen von 12:00am bis 11:59pm",
+	"invalid date %q: use YYYY-MM-DD, today, tomorrow, a weekday like mon, or an offset like +3d": "ungültiges Datum %q: verwende YYYY-MM-DD, today, tomorrow, einen Wochentag wie mon oder einen Versatz wie +3d",
+	"the plan is already paused":                   "der Plan ist bereits pausiert",
+	"the plan has already finished":                "der Plan ist bereits beendet",
+	"the plan is not paused":                       "der Plan ist nicht pausiert",
+	"error parsing on: %w":                         "Fehler beim Lesen von on: %w",
+	"cannot resume before the pause started on %s": "Fortsetzen vor dem Beginn der Pause am %s ist nicht möglich",
+	"confirmation needed, but eepy is not running interactively; pass --yes to confirm": "Bestätigung nötig, aber eepy läuft nicht interaktiv; gib --yes an, um zu bestätigen",
+	"nothing to undo":                                                                       "nichts rückgängig zu machen",
+	"nothing to redo":                                                                       "nichts wiederherzustellen",
+	"error reading revision log: %w":                                                        "Fehler beim Lesen des Fassungsprotokolls: %w",
+	"error reading terminal settings: %w":                                                   "Fehler beim Lesen der Terminaleinstellungen: %w",
+	"error switching terminal to raw mode: %w":                                              "Fehler beim Umschalten des Terminals in den Rohmodus: %w",
+	"the alarms of the clock app cannot be read from Termux: %w":                            "die Alarme der Uhr-App können nicht aus Termux gelesen werden: %w",
+	"tui needs your current wake up time when there is no active plan":                      "tui braucht deine jetzige Aufstehzeit, wenn es keinen aktiven Plan gibt",
+	"error parsing sleep-need: %w":                                                          "Fehler beim Lesen von sleep-need: %w",
+	"tui takes at most one wake up time (HH:MM)":                                            "tui nimmt höchstens eine Aufstehzeit (HH:MM)",
+	"tui needs a terminal; use 'eepy plan new' in scripts":                                  "tui braucht ein Terminal; verwende 'eepy plan new' in Skripten",
+	"invalid device %q: set aliases with device-aliases":                                    "ungültiges Gerät %q: lege Aliasse mit device-aliases fest",
+	"invalid device %q: use a serial like R58M123, an alias or all":                         "ungültiges Gerät %q: verwende eine Seriennummer wie R58M123, einen Alias oder all",
+	"invalid ringtone %q: use silent or a URI like content://media/internal/audio/media/42": "ungültiger Klingelton %q: verwende silent oder eine URI wie content://media/internal/audio/media/42",
+	"unknown locale %q; use a locale for %s, like da_DK":                                    "unbekanntes Gebietsschema %q; verwende eines für %s, etwa da_DK",
+	"the clock app can only skip the next ring of a weekly alarm: %w":                       "die Uhr-App kann bei einem wöchentlichen Wecker nur das nächste Klingeln überspringen: %w",
+	"Warning: error loading config: %v; using the built-in settings\n":                      "Warnung: Fehler beim Laden der Konfiguration: %v; die eingebauten Einstellungen werden verwendet\n",
+}
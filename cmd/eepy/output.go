@@ -0,0 +1,247 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// outputFlag defines the --output flag shared by commands that can print
+// machine-readable output.
+func outputFlag(flags *pflag.FlagSet) *string {
+	return flags.StringP("output", "o", "text", "Output format (text, json, yaml, csv or tsv)")
+}
+
+// tabular is implemented by outputs whose CSV and TSV form is not the value
+// itself, such as a plan whose rows are its days.
+type tabular interface {
+	tableRows() any
+}
+
+// writeOutput writes v to w in format. The text format is left to text. The
+// JSON and YAML forms use the json tags of v; CSV and TSV write one row per
+// element of a slice, or a single row for a struct.
+func writeOutput(w io.Writer, format string, v any, text func(io.Writer)) error {
+	switch format {
+	case "text":
+		text(w)
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		var b strings.Builder
+		writeYAML(&b, reflect.ValueOf(v), 0)
+		_, err := io.WriteString(w, b.String())
+		return err
+	case "csv", "tsv":
+		if t, ok := v.(tabular); ok {
+			v = t.tableRows()
+		}
+		cw := csv.NewWriter(w)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		if err := cw.WriteAll(tableOf(reflect.ValueOf(v))); err != nil {
+			return tr.Errorf("error writing %s: %w", format, err)
+		}
+		return nil
+	}
+	return tr.Errorf("unknown output format %q", format)
+}
+
+// field is an exported struct field with the name from its json tag.
+type field struct {
+	name      string
+	index     int
+	omitEmpty bool
+}
+
+func fieldsOf(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{name: name, index: i, omitEmpty: opts == "omitempty"})
+	}
+	return fields
+}
+
+// scalar formats a value that is neither a struct nor a slice. Strings are
+// quoted for YAML when quote is set.
+func scalar(v reflect.Value, quote bool) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if quote {
+				return "null"
+			}
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if quote {
+			s, _ := json.Marshal(v.String())
+			return string(s)
+		}
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64, reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// tableOf returns a header row followed by one row per record in v.
+func tableOf(v reflect.Value) [][]string {
+	v = reflect.Indirect(v)
+	var records []reflect.Value
+	elem := v.Type()
+	if v.Kind() == reflect.Slice {
+		elem = elem.Elem()
+		for i := 0; i < v.Len(); i++ {
+			records = append(records, v.Index(i))
+		}
+	} else {
+		records = append(records, v)
+	}
+
+	fields := fieldsOf(elem)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	rows := [][]string{header}
+	for _, r := range records {
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i] = scalar(r.Field(f.index), false)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// writeYAML writes v as a YAML block at the given indentation. Only the
+// structs, slices and scalars used by eepy's outputs are supported.
+func writeYAML(b *strings.Builder, v reflect.Value, indent int) {
+	pad := strings.Repeat(" ", indent)
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fieldsOf(v.Type()) {
+			fv := v.Field(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			if fv.Kind() == reflect.Slice && fv.Len() == 0 {
+				fmt.Fprintf(b, "%s%s: []\n", pad, f.name)
+				continue
+			}
+			if k := reflect.Indirect(fv).Kind(); k == reflect.Struct || k == reflect.Slice {
+				fmt.Fprintf(b, "%s%s:\n", pad, f.name)
+				writeYAML(b, fv, indent+2)
+				continue
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", pad, f.name, scalar(fv, true))
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			fmt.Fprintf(b, "%s[]\n", pad)
+		}
+		for i := 0; i < v.Len(); i++ {
+			var item strings.Builder
+			writeYAML(&item, v.Index(i), indent+2)
+			fmt.Fprintf(b, "%s- %s", pad, strings.TrimPrefix(item.String(), pad+"  "))
+		}
+	default:
+		fmt.Fprintf(b, "%s%s\n", pad, scalar(v, true))
+	}
+}
+
+// PlanOutput is the machine-readable form of a plan printed by
+// `--output json|yaml|csv|tsv`. CSV and TSV contain only the days.
+type PlanOutput struct {
+	ID                string      `json:"id"`
+	StartDate         string      `json:"start_date"`
+	InitialWake       string      `json:"initial_wake"`
+	TargetWake        string      `json:"target_wake"`
+	AdjustmentMinutes int         `json:"adjustment_minutes"`
+	Paused            bool        `json:"paused"`
+	TargetReached     bool        `json:"target_reached"`
+	Days              []DayOutput `json:"days"`
+}
+
+// DayOutput is one day of a PlanOutput. Bedtime is on the evening before
+// Date.
+type DayOutput struct {
+	Date          string `json:"date"`
+	Day           int    `json:"day"`
+	Wake          string `json:"wake"`
+	Bedtime       string `json:"bedtime"`
+	SleepMinutes  int    `json:"sleep_minutes"`
+	Today         bool   `json:"today"`
+	TargetReached bool   `json:"target_reached"`
+	Edited        bool   `json:"edited"`
+	Inserted      bool   `json:"inserted"`
+	Note          string `json:"note"`
+}
+
+func (o PlanOutput) tableRows() any {
+	return o.Days
+}
+
+func planOutput(p *Plan) PlanOutput {
+	out := PlanOutput{
+		ID:                p.id(),
+		StartDate:         p.StartDate.Format(dateFormat),
+		InitialWake:       p.InitialWakeTime.Format(timeFormat),
+		TargetWake:        p.TargetWakeTime.Format(timeFormat),
+		AdjustmentMinutes: int(p.Adjustment.Minutes()),
+		Paused:            p.ongoingPause() != nil,
+		Days:              []DayOutput{},
+	}
+	today := timeNow()
+	for _, day := range p.Days() {
+		out.Days = append(out.Days, DayOutput{
+			Date:          day.Date.Format(dateFormat),
+			Day:           day.Number,
+			Wake:          day.Wake.Format(timeFormat),
+			Bedtime:       day.Bedtime.Format(timeFormat),
+			SleepMinutes:  int(day.Wake.Sub(day.Bedtime).Minutes()),
+			Today:         sameDate(day.Date, today),
+			TargetReached: p.ReachesTarget(day.Wake),
+			Edited:        day.WakeOverridden || day.BedtimeOverridden || day.Note != "",
+			Inserted:      day.Inserted,
+			Note:          day.Note,
+		})
+	}
+	if n := len(out.Days); n > 0 {
+		out.TargetReached = out.Days[n-1].TargetReached
+	}
+	return out
+}
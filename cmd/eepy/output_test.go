@@ -0,0 +1,100 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanOutput(t *testing.T) {
+	p := testPlan(t)
+	timeNow = func() time.Time { return testDate("2025-07-14").Add(9 * time.Hour) }
+	defer func() { timeNow = time.Now }()
+
+	out := planOutput(p)
+	if len(out.Days) != 3 || !out.TargetReached {
+		t.Fatalf("Expected 3 days reaching the target, got %+v", out)
+	}
+	day := out.Days[1]
+	if day.Date != "2025-07-14" || day.Day != 2 || day.Wake != "09:00" || day.Bedtime != "00:00" || day.SleepMinutes != 540 {
+		t.Errorf("Unexpected second day: %+v", day)
+	}
+	if !day.Today || out.Days[0].Today {
+		t.Errorf("Expected only the second day to be today: %+v", out.Days)
+	}
+	if out.Days[1].TargetReached || !out.Days[2].TargetReached {
+		t.Errorf("Expected only the last day to reach the target: %+v", out.Days)
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	type record struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Share *float64 `json:"share,omitempty"`
+	}
+	share := 0.5
+	records := []record{{Name: "a, b", Count: 1, Share: &share}, {Name: "c", Count: 2}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "name,count,share\n\"a, b\",1,0.5\nc,2,\n"},
+		{"tsv", "name\tcount\tshare\na, b\t1\t0.5\nc\t2\t\n"},
+		{"yaml", "- name: \"a, b\"\n  count: 1\n  share: 0.5\n- name: \"c\"\n  count: 2\n"},
+		{"text", "text\n"},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		err := writeOutput(&b, test.format, records, func(w io.Writer) { io.WriteString(w, "text\n") })
+		if err != nil || b.String() != test.want {
+			t.Errorf("%s: expected %q, got %q (%v)", test.format, test.want, b.String(), err)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := writeOutput(&b, "json", records, nil); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []record
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil || len(decoded) != 2 || decoded[1].Share != nil {
+		t.Errorf("Expected the JSON to round-trip, got %+v (%v)", decoded, err)
+	}
+
+	if err := writeOutput(&b, "xml", records, nil); err == nil || !strings.Contains(err.Error(), "unknown output format") {
+		t.Errorf("Expected an unknown format error, got %v", err)
+	}
+}
+
+func TestPlanShowOutput(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if code, _, stderr := runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-07-13"); code != 0 {
+		t.Fatalf("plan new failed: %s", stderr)
+	}
+
+	code, stdout, _ := runTest(t, "", "plan", "show", "--output", "csv")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 4 || lines[0] != "date,day,wake,bedtime,sleep_minutes,today,target_reached,edited,inserted,note" {
+		t.Errorf("Expected a header and three days, got %d: %s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "stats", "-o", "yaml")
+	if code != 0 || !strings.Contains(stdout, "nights: 0\n") {
+		t.Errorf("Expected stats as YAML, got %d: %s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "history", "-o", "json")
+	if code != 0 || strings.TrimSpace(stdout) != "[]" {
+		t.Errorf("Expected an empty JSON history, got %d: %s", code, stdout)
+	}
+}
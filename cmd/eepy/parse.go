@@ -0,0 +1,126 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseClock parses a wall clock time. Besides HH:MM it accepts an hour on
+// its own ("7"), compact forms ("0630", "630"), a dot as separator ("7.30"),
+// 12-hour times ("7am", "6:30 pm") and "noon" and "midnight". The result is
+// on the zero date, like time.Parse(timeFormat, s).
+func parseClock(s string) (time.Time, error) {
+	invalid := tr.Errorf("invalid time %q: use HH:MM like 07:30, or a 12-hour time like 7:30am", s)
+	t := strings.ToLower(strings.TrimSpace(s))
+	switch t {
+	case "noon":
+		t = "12:00"
+	case "midnight":
+		t = "00:00"
+	}
+
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm", "a.m.", "p.m.", "a", "p"} {
+		if rest, ok := strings.CutSuffix(t, suffix); ok {
+			meridiem, t = suffix[:1], strings.TrimSpace(rest)
+			break
+		}
+	}
+
+	var hourStr, minuteStr string
+	if h, m, ok := strings.Cut(strings.ReplaceAll(t, ".", ":"), ":"); ok {
+		hourStr, minuteStr = h, m
+		if len(minuteStr) != 2 {
+			return time.Time{}, invalid
+		}
+	} else {
+		switch len(t) {
+		case 1, 2:
+			hourStr, minuteStr = t, "00"
+		case 3, 4:
+			hourStr, minuteStr = t[:len(t)-2], t[len(t)-2:]
+		default:
+			return time.Time{}, invalid
+		}
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hourStr == "" || hourStr[0] == '+' || hourStr[0] == '-' {
+		return time.Time{}, invalid
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minuteStr[0] == '+' || minuteStr[0] == '-' || minute > 59 {
+		return time.Time{}, invalid
+	}
+
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return time.Time{}, invalid
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return time.Time{}, tr.Errorf("invalid time %q: 12-hour times run from 12:00am to 11:59pm", s)
+		}
+		hour %= 12
+		if meridiem == "p" {
+			hour += 12
+		}
+	}
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC), nil
+}
+
+// parseDate parses a date as YYYY-MM-DD or relative to today: "today",
+// "tomorrow", "yesterday", a weekday ("mon", "friday"), which means today or
+// the next such day, or an offset in days or weeks ("+3d", "-1w"). The
+// result is midnight in loc.
+func parseDate(s string, today time.Time, loc *time.Location) (time.Time, error) {
+	t := strings.ToLower(strings.TrimSpace(s))
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+	switch t {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if len(t) >= 3 {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if name := strings.ToLower(day.String()); strings.HasPrefix(name, t) {
+				return today.AddDate(0, 0, (int(day)-int(today.Weekday())+7)%7), nil
+			}
+		}
+	}
+
+	if strings.HasPrefix(t, "+") || strings.HasPrefix(t, "-") {
+		days := 1
+		switch {
+		case strings.HasSuffix(t, "d"):
+			t = strings.TrimSuffix(t, "d")
+		case strings.HasSuffix(t, "w"):
+			t, days = strings.TrimSuffix(t, "w"), 7
+		}
+		if n, err := strconv.Atoi(t); err == nil {
+			return today.AddDate(0, 0, n*days), nil
+		}
+	} else if date, err := time.Parse(dateFormat, t); err == nil {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, tr.Errorf("invalid date %q: use YYYY-MM-DD, today, tomorrow, a weekday like mon, or an offset like +3d", s)
+}
+
+// parseDateFlag parses a date flag in loc. An empty flag means today.
+func parseDateFlag(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		s = "today"
+	}
+	return parseDate(s, timeNow(), loc)
+}
@@ -0,0 +1,111 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"07:30", "07:30"},
+		{"7:30", "07:30"},
+		{"23:59", "23:59"},
+		{"7", "07:00"},
+		{"19", "19:00"},
+		{"0630", "06:30"},
+		{"630", "06:30"},
+		{"7.30", "07:30"},
+		{"7am", "07:00"},
+		{"7 AM", "07:00"},
+		{"6:30 pm", "18:30"},
+		{"6:30p", "18:30"},
+		{"11.15 p.m.", "23:15"},
+		{"12am", "00:00"},
+		{"12:30am", "00:30"},
+		{"12pm", "12:00"},
+		{"noon", "12:00"},
+		{"Midnight", "00:00"},
+		{" 08:00 ", "08:00"},
+	}
+	for _, test := range tests {
+		got, err := parseClock(test.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.input, err)
+			continue
+		}
+		if got.Format(timeFormat) != test.want || got.Year() != 0 {
+			t.Errorf("%q: expected %s, got %s", test.input, test.want, got)
+		}
+	}
+}
+
+func TestParseClockErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		hint  string
+	}{
+		{"", "use HH:MM"},
+		{"24:00", "use HH:MM"},
+		{"7:60", "use HH:MM"},
+		{"7:5", "use HH:MM"},
+		{"07305", "use HH:MM"},
+		{"seven", "use HH:MM"},
+		{"-7", "use HH:MM"},
+		{"13pm", "12-hour times run from"},
+		{"0am", "12-hour times run from"},
+	}
+	for _, test := range tests {
+		_, err := parseClock(test.input)
+		if err == nil || !strings.Contains(err.Error(), test.hint) {
+			t.Errorf("%q: expected an error containing %q, got %v", test.input, test.hint, err)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	// Wednesday.
+	today := time.Date(2025, 7, 16, 21, 30, 0, 0, time.Local)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-08-01", "2025-08-01"},
+		{"today", "2025-07-16"},
+		{"Tomorrow", "2025-07-17"},
+		{"yesterday", "2025-07-15"},
+		{"wed", "2025-07-16"},
+		{"thu", "2025-07-17"},
+		{"mon", "2025-07-21"},
+		{"sunday", "2025-07-20"},
+		{"+3d", "2025-07-19"},
+		{"+3", "2025-07-19"},
+		{"-2d", "2025-07-14"},
+		{"+1w", "2025-07-23"},
+	}
+	for _, test := range tests {
+		got, err := parseDate(test.input, today, time.UTC)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.input, err)
+			continue
+		}
+		if got.Format(dateFormat) != test.want || got.Location() != time.UTC || got.Hour() != 0 {
+			t.Errorf("%q: expected %s, got %s", test.input, test.want, got)
+		}
+	}
+
+	for _, input := range []string{"", "mo", "next week", "+d", "2025-13-01", "16/07/2025"} {
+		if _, err := parseDate(input, today, time.UTC); err == nil || !strings.Contains(err.Error(), "use YYYY-MM-DD") {
+			t.Errorf("%q: expected an error suggesting the right form, got %v", input, err)
+		}
+	}
+}
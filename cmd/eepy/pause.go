@@ -0,0 +1,144 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Pause is an interval in which the plan was put on hold. Start is the first
+// paused date and End the date the plan resumed on, which is zero while the
+// plan is still paused.
+type Pause struct {
+	Start   time.Time
+	End     time.Time
+	Created time.Time
+}
+
+// length is the number of days the remaining plan is moved back by. While a
+// pause is ongoing, the plan is laid out as if it resumes tomorrow.
+func (pause Pause) length() int {
+	end := pause.End
+	if end.IsZero() {
+		end = dateOf(timeNow()).AddDate(0, 0, 1)
+		if !end.After(pause.Start) {
+			end = pause.Start.AddDate(0, 0, 1)
+		}
+	}
+	start := dateOf(pause.Start)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, start.Location())
+	return int(end.Sub(start).Hours()+12) / 24
+}
+
+// ongoingPause returns the pause the plan is currently in, if any.
+func (p *Plan) ongoingPause() *Pause {
+	for i := range p.Pauses {
+		if p.Pauses[i].End.IsZero() {
+			return &p.Pauses[i]
+		}
+	}
+	return nil
+}
+
+// InPause reports whether date falls in a pause of the plan. While the plan
+// is paused, every date from the start of the pause on does.
+func (p *Plan) InPause(date time.Time) bool {
+	date = dateOf(date)
+	for _, pause := range p.Pauses {
+		if date.Before(dateOf(pause.Start)) {
+			continue
+		}
+		if pause.End.IsZero() || date.Before(dateOf(pause.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+func pauseCommand(flags *pflag.FlagSet) runFunc {
+	fromStr := flags.String("from", "", "First day to pause (YYYY-MM-DD, default: today)")
+
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		if p.ongoingPause() != nil {
+			return tr.Errorf("the plan is already paused")
+		}
+		from, err := parseDateFlag(*fromStr, p.StartDate.Location())
+		if err != nil {
+			return tr.Errorf("error parsing from: %w", err)
+		}
+		days := p.Days()
+		if from.After(days[len(days)-1].Date) {
+			return tr.Errorf("the plan has already finished")
+		}
+		if err := ensureRevisionBaseline(p); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+
+		p.Pauses = append(p.Pauses, Pause{Start: from, Created: timeNow()})
+		if err := savePlan(p); err != nil {
+			return tr.Errorf("error saving plan: %w", err)
+		}
+		if err := recordRevision(revisionPause, p); err != nil {
+			return tr.Errorf("error recording plan revision: %w", err)
+		}
+		tr.Fprintf(a.stdout, "Plan paused from %s. Run 'eepy plan resume' to continue where you left off.\n", tr.day(from))
+		a.dismissStaleAlarms(func(c CreatedAlarm) bool { return c.Plan == p.id() && !c.Time.Before(from) })
+		return nil
+	}
+}
+
+func resumeCommand(flags *pflag.FlagSet) runFunc {
+	onStr := flags.String("on", "", "Day to resume the plan on (YYYY-MM-DD, default: today)")
+
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		pause := p.ongoingPause()
+		if pause == nil {
+			return tr.Errorf("the plan is not paused")
+		}
+		on, err := parseDateFlag(*onStr, p.StartDate.Location())
+		if err != nil {
+			return tr.Errorf("error parsing on: %w", err)
+		}
+		if on.Before(dateOf(pause.Start)) {
+			return tr.Errorf("cannot resume before the pause started on %s", pause.Start.Format(dateFormat))
+		}
+		if err := ensureRevisionBaseline(p); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+
+		if on.Equal(dateOf(pause.Start)) {
+			// Resuming on the day the pause started leaves nothing to shift.
+			kept := p.Pauses[:0]
+			for _, other := range p.Pauses {
+				if !other.End.IsZero() {
+					kept = append(kept, other)
+				}
+			}
+			p.Pauses = kept
+		} else {
+			pause.End = on
+		}
+		if err := savePlan(p); err != nil {
+			return tr.Errorf("error saving plan: %w", err)
+		}
+		if err := recordRevision(revisionResume, p); err != nil {
+			return tr.Errorf("error recording plan revision: %w", err)
+		}
+		displayPlan(a.stdout, p)
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDaysAfterPause(t *testing.T) {
+	p := testPlan(t)
+	p.Pauses = []Pause{{Start: testDate("2025-07-14"), End: testDate("2025-07-17")}}
+	days := p.Days()
+
+	want := []string{"2025-07-13 10:00", "2025-07-17 09:00", "2025-07-18 08:00"}
+	for i, d := range days {
+		if got := d.Wake.Format("2006-01-02 15:04"); got != want[i] {
+			t.Errorf("Expected day %d to wake at %s, got %s", i+1, want[i], got)
+		}
+	}
+	if !p.InPause(testDate("2025-07-16")) || p.InPause(testDate("2025-07-17")) {
+		t.Errorf("Expected the pause to cover Jul 14 to Jul 16")
+	}
+}
+
+func TestDaysDuringOngoingPause(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	p := testPlan(t)
+	p.Pauses = []Pause{{Start: testDate("2025-07-14")}}
+	days := p.Days()
+
+	if got := days[1].Date.Format(dateFormat); got != "2025-07-16" {
+		t.Errorf("Expected the paused day to move to tomorrow, got %s", got)
+	}
+	if !p.InPause(testDate("2025-07-20")) {
+		t.Errorf("Expected every date after the start of an ongoing pause to be paused")
+	}
+}
+
+func TestPauseAndInsertApplyInOrder(t *testing.T) {
+	p := testPlan(t)
+	p.Pauses = []Pause{{Start: testDate("2025-07-14"), End: testDate("2025-07-16"), Created: time.Unix(1, 0)}}
+	p.Overrides = []Override{{Kind: overrideInsert, Date: testDate("2025-07-16"), Created: time.Unix(2, 0)}}
+	days := p.Days()
+
+	if len(days) != 4 {
+		t.Fatalf("Expected 4 days, got %d", len(days))
+	}
+	if !days[1].Inserted || days[1].Date.Format(dateFormat) != "2025-07-16" || days[1].Wake.Format(timeFormat) != "09:00" {
+		t.Errorf("Expected the insert to repeat the resumed day on Jul 16, got %s at %s", days[1].Date.Format(dateFormat), days[1].Wake.Format(timeFormat))
+	}
+}
+
+func TestPauseAndEditFollowTheClock(t *testing.T) {
+	setConfigDir(t.TempDir())
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	runTest(t, "", "plan", "new", "10:00", "--target", "08:00", "--adjustment", "1h", "--start-date", "2030-01-02")
+	now = now.Add(time.Minute)
+	runTest(t, "", "plan", "pause", "--from", "2030-01-04")
+	now = now.Add(time.Minute)
+	if code, _, stderr := runTest(t, "", "plan", "edit", "2030-01-03", "--wake", "08:30"); code != 0 {
+		t.Fatalf("Unexpected error editing the plan: %s", stderr)
+	}
+
+	p, err := loadPlan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "20300101T120000.000000" {
+		t.Errorf("Expected the plan to be named after the time it was made, got %s", p.ID)
+	}
+	if len(p.Pauses) != 1 || len(p.Overrides) != 1 || !p.Pauses[0].Created.Before(p.Overrides[0].Created) || !p.Overrides[0].Created.Equal(now) {
+		t.Errorf("Expected the edit to be made after the pause, got %+v and %+v", p.Pauses, p.Overrides)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prompter asks the user to confirm an action.
+type prompter interface {
+	Confirm(question string) (bool, error)
+}
+
+// linePrompter asks on out and reads a y/N answer from a line of in.
+type linePrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newLinePrompter(in io.Reader, out io.Writer) *linePrompter {
+	return &linePrompter{in: bufio.NewReader(in), out: out}
+}
+
+func (p *linePrompter) Confirm(question string) (bool, error) {
+	fmt.Fprintf(p.out, "%s %s ", question, tr.T("(y/N):"))
+	input, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "y" || answer == "yes" || answer == tr.T("y") || answer == tr.T("yes"), nil
+}
+
+// errNeedsConfirmation is returned when an action needs confirming but
+// eepy may not ask for it.
+var errNeedsConfirmation = translatedError("confirmation needed, but eepy is not running interactively; pass --yes to confirm")
+
+// isTerminal reports whether r is a terminal.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && isTerminalFile(f)
+}
+
+// confirm asks question unless --yes answers it or input is disabled by
+// --no-input or a stdin that is not a terminal.
+func (a *app) confirm(question string) (bool, error) {
+	if a.assumeYes {
+		return true, nil
+	}
+	if a.noInput || a.prompt == nil {
+		return false, tr.Errorf("%s: %w", question, errNeedsConfirmation)
+	}
+	return a.prompt.Confirm(question)
+}
@@ -0,0 +1,117 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// scriptedPrompter answers confirmations from a list and records the
+// questions it was asked.
+type scriptedPrompter struct {
+	answers   []bool
+	questions []string
+}
+
+func (p *scriptedPrompter) Confirm(question string) (bool, error) {
+	p.questions = append(p.questions, question)
+	if len(p.answers) == 0 {
+		return false, errors.New("unexpected prompt: " + question)
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return answer, nil
+}
+
+func TestConfirm(t *testing.T) {
+	a := &app{prompt: &scriptedPrompter{answers: []bool{true}}}
+	if ok, err := a.confirm("Proceed?"); err != nil || !ok {
+		t.Errorf("Expected the scripted answer, got %v, %v", ok, err)
+	}
+
+	a = &app{assumeYes: true}
+	if ok, err := a.confirm("Proceed?"); err != nil || !ok {
+		t.Errorf("Expected --yes to confirm without a prompter, got %v, %v", ok, err)
+	}
+
+	a = &app{noInput: true, prompt: &scriptedPrompter{answers: []bool{true}}}
+	if _, err := a.confirm("Proceed?"); !errors.Is(err, errNeedsConfirmation) {
+		t.Errorf("Expected --no-input to refuse prompting, got %v", err)
+	}
+
+	a = &app{}
+	if _, err := a.confirm("Proceed?"); !errors.Is(err, errNeedsConfirmation) {
+		t.Errorf("Expected no prompter to refuse prompting, got %v", err)
+	}
+}
+
+func TestLinePrompter(t *testing.T) {
+	var out bytes.Buffer
+	p := newLinePrompter(strings.NewReader("Yes\nn\n"), &out)
+	if ok, _ := p.Confirm("First?"); !ok {
+		t.Error("Expected 'Yes' to confirm")
+	}
+	if ok, _ := p.Confirm("Second?"); ok {
+		t.Error("Expected 'n' to decline")
+	}
+	if ok, err := p.Confirm("Third?"); ok || err != nil {
+		t.Errorf("Expected end of input to decline, got %v, %v", ok, err)
+	}
+	if !strings.Contains(out.String(), "First? (y/N): ") {
+		t.Errorf("Expected the question to be printed, got %q", out.String())
+	}
+}
+
+func TestOverridePrompt(t *testing.T) {
+	setConfigDir(t.TempDir())
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"plan", "new", "08:00", "--target", "07:00", "--start-date", "2025-07-13"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("plan new failed: %s", stderr.String())
+	}
+
+	prompt := &scriptedPrompter{answers: []bool{false}}
+	a := &app{stdout: &stdout, stderr: &stderr, prompt: prompt}
+	if code := a.exec([]string{"plan", "new", "09:00"}); code != 0 || !strings.Contains(stdout.String(), "Operation cancelled.") {
+		t.Errorf("Expected declining the override to cancel, got %d: %s", code, stdout.String())
+	}
+	if len(prompt.questions) != 1 || !strings.Contains(prompt.questions[0], "override") {
+		t.Errorf("Expected one override question, got %q", prompt.questions)
+	}
+
+	stdout.Reset()
+	a = &app{stdout: &stdout, stderr: &stderr}
+	if code := a.exec([]string{"plan", "new", "09:00", "--start-date", "2025-07-13", "--force"}); code != 0 || !strings.Contains(stdout.String(), "Wake up at 07:30") {
+		t.Errorf("Expected --force to override without asking, got %d: %s%s", code, stdout.String(), stderr.String())
+	}
+}
+
+func TestDevNullIsNotATerminal(t *testing.T) {
+	setConfigDir(t.TempDir())
+	runTest(t, "", "plan", "new", "10:00", "--target", "09:00", "--adjustment", "30m", "--start-date", "2025-01-01")
+
+	// cron and systemd run eepy with /dev/null as stdin, which is a
+	// character device but no terminal.
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-01-01"}, devNull, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "confirmation needed") || strings.Contains(stdout.String(), "(y/N)") {
+		t.Errorf("Expected replacing the plan to need --yes, got %d:\n%s%s", code, stdout.String(), stderr.String())
+	}
+	stderr.Reset()
+	if code := run([]string{"tui"}, devNull, devNull, &stderr); code != 1 || !strings.Contains(stderr.String(), "tui needs a terminal") {
+		t.Errorf("Expected tui to refuse /dev/null, got %d: %s", code, stderr.String())
+	}
+}
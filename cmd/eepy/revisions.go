@@ -0,0 +1,190 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// RevisionAction describes what produced a revision of the active plan.
+type RevisionAction string
+
+const (
+	revisionCreate   RevisionAction = "create"
+	revisionEdit     RevisionAction = "edit"
+	revisionReplan   RevisionAction = "replan"
+	revisionOverride RevisionAction = "override"
+	revisionPause    RevisionAction = "pause"
+	revisionResume   RevisionAction = "resume"
+)
+
+// Revision is a snapshot of the active plan taken after a change to it.
+type Revision struct {
+	Action RevisionAction
+	Time   time.Time
+	Plan   *Plan
+	// Archived is the history file an override moved the plan it replaced
+	// to, so that undoing it can take the file back out of the history.
+	Archived string `json:",omitempty"`
+}
+
+// RevisionLog is the undo history of the active plan. Current is the index
+// of the revision that plan.json holds; revisions after it can be redone.
+type RevisionLog struct {
+	Revisions []Revision
+	Current   int
+}
+
+var (
+	errNothingToUndo = translatedError("nothing to undo")
+	errNothingToRedo = translatedError("nothing to redo")
+)
+
+func loadRevisionLog() (*RevisionLog, error) {
+	data, err := os.ReadFile(revisionsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &RevisionLog{Current: -1}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var log RevisionLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, tr.Errorf("error reading revision log: %w", err)
+	}
+	return &log, nil
+}
+
+func saveRevisionLog(log *RevisionLog) error {
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(revisionsPath, data, 0644)
+}
+
+// record appends a revision after the current one, discarding anything that
+// could previously have been redone.
+func (l *RevisionLog) record(action RevisionAction, p *Plan) {
+	l.Revisions = append(l.Revisions[:l.Current+1], Revision{
+		Action: action,
+		Time:   timeNow(),
+		Plan:   p,
+	})
+	l.Current = len(l.Revisions) - 1
+}
+
+func recordRevision(action RevisionAction, p *Plan) error {
+	log, err := loadRevisionLog()
+	if err != nil {
+		return err
+	}
+	log.record(action, p)
+	return saveRevisionLog(log)
+}
+
+// recordOverride records p replacing the active plan, which was moved to
+// the history file archived.
+func recordOverride(p *Plan, archived string) error {
+	log, err := loadRevisionLog()
+	if err != nil {
+		return err
+	}
+	log.record(revisionOverride, p)
+	log.Revisions[log.Current].Archived = archived
+	return saveRevisionLog(log)
+}
+
+// ensureRevisionBaseline records p as the first revision if the log is empty,
+// so that plans created before revisions were tracked can still be restored.
+func ensureRevisionBaseline(p *Plan) error {
+	log, err := loadRevisionLog()
+	if err != nil {
+		return err
+	}
+	if len(log.Revisions) > 0 {
+		return nil
+	}
+	log.record(revisionCreate, p)
+	return saveRevisionLog(log)
+}
+
+// stepRevision moves the current revision by delta and writes the plan it
+// points at back to plan.json. Undoing an override takes the plan it
+// replaced back out of the history, and redoing it archives that plan
+// again. It returns the restored revision and the revision whose change
+// was undone or redone.
+func stepRevision(delta int) (restored, changed *Revision, err error) {
+	log, err := loadRevisionLog()
+	if err != nil {
+		return nil, nil, err
+	}
+	next := log.Current + delta
+	if next < 0 {
+		return nil, nil, errNothingToUndo
+	}
+	if next >= len(log.Revisions) {
+		return nil, nil, errNothingToRedo
+	}
+	restored = &log.Revisions[next]
+	changed = restored
+	if delta < 0 {
+		changed = &log.Revisions[log.Current]
+	}
+	if delta > 0 && restored.Archived != "" {
+		if restored.Archived, err = archivePlan(log.Revisions[log.Current].Plan); err != nil {
+			return nil, nil, tr.Errorf("error archiving existing plan: %w", err)
+		}
+	}
+	if err := savePlan(restored.Plan); err != nil {
+		return nil, nil, err
+	}
+	if delta < 0 && changed.Archived != "" {
+		if err := os.Remove(filepath.Join(historyPath, changed.Archived)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+	}
+	log.Current = next
+	if err := saveRevisionLog(log); err != nil {
+		return nil, nil, err
+	}
+	return restored, changed, nil
+}
+
+func undoCommand(flags *pflag.FlagSet) runFunc {
+	return stepRevisionCommand(flags, "Undid", -1)
+}
+
+func redoCommand(flags *pflag.FlagSet) runFunc {
+	return stepRevisionCommand(flags, "Redid", 1)
+}
+
+func stepRevisionCommand(flags *pflag.FlagSet, verb string, delta int) runFunc {
+	withAlarms := flags.BoolP("sync-alarms", "a", false, "Set alarms for the restored plan")
+	alarms := newAlarmFlags(flags)
+
+	return func(a *app, args []string) error {
+		restored, changed, err := stepRevision(delta)
+		if err != nil {
+			return err
+		}
+
+		tr.Fprintf(a.stdout, verb+" %s from %s.\n", tr.T(string(changed.Action)), tr.day(changed.Time)+" "+tr.clock(changed.Time))
+		displayPlan(a.stdout, restored.Plan)
+
+		if *withAlarms {
+			return alarms.sync(a, restored.Plan)
+		}
+		return nil
+	}
+}
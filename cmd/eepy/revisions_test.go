@@ -0,0 +1,129 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUndoRedoRevisions(t *testing.T) {
+	setConfigDir(t.TempDir())
+
+	first := &Plan{Adjustment: time.Hour}
+	second := &Plan{Adjustment: 2 * time.Hour}
+	if err := recordRevision(revisionCreate, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordRevision(revisionOverride, second); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, changed, err := stepRevision(-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Plan.Adjustment != time.Hour || changed.Action != revisionOverride {
+		t.Errorf("Expected undo of override to restore first plan, got %v after undoing %s", restored.Plan.Adjustment, changed.Action)
+	}
+	if p, _ := loadPlan(); p.Adjustment != time.Hour {
+		t.Errorf("Expected plan.json to hold the first plan, got %v", p.Adjustment)
+	}
+
+	if _, _, err := stepRevision(-1); err != errNothingToUndo {
+		t.Errorf("Expected %v, got %v", errNothingToUndo, err)
+	}
+
+	restored, _, err = stepRevision(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Plan.Adjustment != 2*time.Hour {
+		t.Errorf("Expected redo to restore second plan, got %v", restored.Plan.Adjustment)
+	}
+	if _, _, err := stepRevision(1); err != errNothingToRedo {
+		t.Errorf("Expected %v, got %v", errNothingToRedo, err)
+	}
+}
+
+func TestRecordRevisionDiscardsRedo(t *testing.T) {
+	setConfigDir(t.TempDir())
+
+	for _, adjustment := range []time.Duration{time.Hour, 2 * time.Hour} {
+		if err := recordRevision(revisionCreate, &Plan{Adjustment: adjustment}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := stepRevision(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordRevision(revisionOverride, &Plan{Adjustment: 3 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := loadRevisionLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Revisions) != 2 || log.Current != 1 {
+		t.Errorf("Expected 2 revisions with the last current, got %d with current %d", len(log.Revisions), log.Current)
+	}
+	if _, _, err := stepRevision(1); err != errNothingToRedo {
+		t.Errorf("Expected %v, got %v", errNothingToRedo, err)
+	}
+}
+
+func TestUndoOverrideRestoresHistory(t *testing.T) {
+	setConfigDir(t.TempDir())
+	archived := func() int {
+		plans, err := listArchivedPlans()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(plans)
+	}
+
+	if err := replacePlan(&Plan{Adjustment: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if err := replacePlan(&Plan{Adjustment: 2 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if n := archived(); n != 1 {
+		t.Fatalf("Expected the first plan in the history, got %d plans", n)
+	}
+
+	if _, _, err := stepRevision(-1); err != nil {
+		t.Fatal(err)
+	}
+	if n := archived(); n != 0 {
+		t.Errorf("Expected undoing the override to take the active plan out of the history, got %d plans", n)
+	}
+	if _, _, err := stepRevision(1); err != nil {
+		t.Fatal(err)
+	}
+	if n := archived(); n != 1 {
+		t.Errorf("Expected redoing the override to archive the first plan again, got %d plans", n)
+	}
+
+	if _, _, err := stepRevision(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err := replacePlan(&Plan{Adjustment: 3 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	plans, err := listArchivedPlans()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("Expected the first plan to be archived once, got %d plans", len(plans))
+	}
+	if p, err := loadPlanFile(plans[0].path); err != nil || p.Adjustment != time.Hour {
+		t.Errorf("Expected the first plan in the history, got %+v, %v", p, err)
+	}
+}
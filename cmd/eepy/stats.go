@@ -0,0 +1,267 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Stats summarises how logged nights compare with the plan. Durations are
+// whole minutes so the JSON output is easy to consume. Fields that cannot be
+// computed from the logged nights are left out.
+type Stats struct {
+	From             string `json:"from,omitempty"`
+	To               string `json:"to,omitempty"`
+	Nights           int    `json:"nights"`
+	PlannedNights    int    `json:"planned_nights"`
+	ToleranceMinutes int    `json:"tolerance_minutes"`
+
+	MeanWakeDeviationMinutes    *float64 `json:"mean_wake_deviation_minutes,omitempty"`
+	MeanBedtimeDeviationMinutes *float64 `json:"mean_bedtime_deviation_minutes,omitempty"`
+	WithinTolerance             *float64 `json:"within_tolerance,omitempty"`
+
+	MeanSleepMinutes   *float64 `json:"mean_sleep_minutes,omitempty"`
+	MinSleepMinutes    int      `json:"min_sleep_minutes"`
+	NightsBelowMinimum int      `json:"nights_below_minimum"`
+	SleepDebtMinutes   int      `json:"sleep_debt_minutes"`
+
+	SleepRegularityIndex *float64 `json:"sleep_regularity_index,omitempty"`
+	SocialJetLagMinutes  *float64 `json:"social_jet_lag_minutes,omitempty"`
+}
+
+func minutes(d time.Duration) float64 {
+	return math.Round(d.Minutes()*10) / 10
+}
+
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	m := math.Round(sum/float64(len(values))*10) / 10
+	return &m
+}
+
+// computeStats compares diary entries with the days of p, which may be nil.
+// Deviations are positive when you went to bed or woke up later than planned.
+func computeStats(p *Plan, entries []DiaryEntry, tolerance time.Duration) Stats {
+	stats := Stats{
+		Nights:           len(entries),
+		ToleranceMinutes: int(tolerance.Minutes()),
+		MinSleepMinutes:  int(minSleepDuration.Minutes()),
+	}
+
+	var days []Day
+	if p != nil {
+		days = p.Days()
+	}
+
+	sleepNeed := idealSleepDuration
+	if p != nil {
+		sleepNeed = p.sleepNeed()
+	}
+
+	var wakeDeviations, bedtimeDeviations, sleep []float64
+	within := 0
+	var debt time.Duration
+	for _, e := range entries {
+		if slept := e.SleepDuration(); slept > 0 {
+			sleep = append(sleep, slept.Minutes())
+			if slept < minSleepDuration {
+				stats.NightsBelowMinimum++
+			}
+			debt += sleepNeed - slept
+			if debt < 0 {
+				debt = 0
+			}
+		}
+
+		i := dayIndex(days, e.Date)
+		if i < 0 {
+			continue
+		}
+		day := days[i]
+		stats.PlannedNights++
+		wakeDeviation := e.FinalWake.Sub(day.Wake)
+		wakeDeviations = append(wakeDeviations, minutes(wakeDeviation))
+		ok := wakeDeviation.Abs() <= tolerance
+		if !e.Bedtime.IsZero() {
+			bedtimeDeviation := e.Bedtime.Sub(day.Bedtime)
+			bedtimeDeviations = append(bedtimeDeviations, minutes(bedtimeDeviation))
+			ok = ok && bedtimeDeviation.Abs() <= tolerance
+		}
+		if ok {
+			within++
+		}
+	}
+
+	stats.MeanWakeDeviationMinutes = mean(wakeDeviations)
+	stats.MeanBedtimeDeviationMinutes = mean(bedtimeDeviations)
+	if stats.PlannedNights > 0 {
+		share := math.Round(float64(within)/float64(stats.PlannedNights)*1000) / 1000
+		stats.WithinTolerance = &share
+	}
+	stats.MeanSleepMinutes = mean(sleep)
+	stats.SleepDebtMinutes = int(debt.Minutes())
+	stats.SleepRegularityIndex = sleepRegularityIndex(entries)
+	stats.SocialJetLagMinutes = socialJetLag(entries)
+	return stats
+}
+
+// asleepAt reports whether the entry has you asleep at t.
+func (e DiaryEntry) asleepAt(t time.Time) bool {
+	onset := e.SleepOnset
+	if onset.IsZero() {
+		onset = e.Bedtime
+	}
+	if onset.IsZero() || t.Before(onset) || !t.Before(e.FinalWake) {
+		return false
+	}
+	for _, a := range e.Awakenings {
+		if !t.Before(a.Time) && t.Before(a.Time.Add(a.Duration)) {
+			return false
+		}
+	}
+	return true
+}
+
+// sleepRegularityIndex is the probability of being in the same state, asleep
+// or awake, at any two time points 24 hours apart, scaled from -100 to 100.
+// Each entry covers the 24 hours from noon before its date, and only minutes
+// where both nights were logged are compared.
+func sleepRegularityIndex(entries []DiaryEntry) *float64 {
+	nights := make(map[time.Time]DiaryEntry)
+	for _, e := range entries {
+		nights[dateOf(e.Date)] = e
+	}
+
+	same, total := 0, 0
+	for date, night := range nights {
+		next, ok := nights[date.AddDate(0, 0, 1)]
+		if !ok {
+			continue
+		}
+		start := atClock(date.AddDate(0, 0, -1), time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC))
+		for m := 0; m < 24*60; m++ {
+			t := start.Add(time.Duration(m) * time.Minute)
+			if night.asleepAt(t) == next.asleepAt(t.AddDate(0, 0, 1)) {
+				same++
+			}
+			total++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sri := math.Round((-100+200*float64(same)/float64(total))*10) / 10
+	return &sri
+}
+
+// midSleep is the middle of the sleep period, in minutes since the noon
+// before the entry's date.
+func (e DiaryEntry) midSleep() (float64, bool) {
+	onset := e.SleepOnset
+	if onset.IsZero() {
+		onset = e.Bedtime
+	}
+	if onset.IsZero() {
+		return 0, false
+	}
+	noon := atClock(e.Date.AddDate(0, 0, -1), time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC))
+	mid := onset.Add(e.FinalWake.Sub(onset) / 2)
+	return mid.Sub(noon).Minutes(), true
+}
+
+// socialJetLag is the absolute difference between the mean mid-sleep of
+// nights before free days (Saturday and Sunday mornings) and of nights before
+// work days.
+func socialJetLag(entries []DiaryEntry) *float64 {
+	var free, work []float64
+	for _, e := range entries {
+		mid, ok := e.midSleep()
+		if !ok {
+			continue
+		}
+		switch e.Date.Weekday() {
+		case time.Saturday, time.Sunday:
+			free = append(free, mid)
+		default:
+			work = append(work, mid)
+		}
+	}
+	msf, msw := mean(free), mean(work)
+	if msf == nil || msw == nil {
+		return nil
+	}
+	sjl := math.Round(math.Abs(*msf-*msw)*10) / 10
+	return &sjl
+}
+
+func statsCommand(flags *pflag.FlagSet) runFunc {
+	fromStr := flags.String("from", "", "First morning to include (YYYY-MM-DD)")
+	toStr := flags.String("to", "", "Last morning to include (YYYY-MM-DD)")
+	tolerance := flags.Duration("tolerance", 30*time.Minute, "How far from the plan a night may be and still count as on plan")
+	output := outputFlag(flags)
+	configurable(flags, "tolerance")
+
+	return func(a *app, args []string) error {
+		entries, err := loadDiary()
+		if err != nil {
+			return err
+		}
+		entries, err = filterDiary(entries, *fromStr, *toStr)
+		if err != nil {
+			return err
+		}
+		p, err := loadPlan()
+		if err != nil {
+			p = nil
+		}
+
+		stats := computeStats(p, entries, *tolerance)
+		stats.From, stats.To = *fromStr, *toStr
+
+		return writeOutput(a.stdout, *output, stats, func(w io.Writer) { displayStats(w, stats) })
+	}
+}
+
+func displayStats(w io.Writer, s Stats) {
+	tr.Fprintln(w, "Your sleep statistics:")
+	fmt.Fprintln(w, "-----------------------------")
+	if s.Nights == 0 {
+		tr.Fprintln(w, "No nights logged. Record one with 'eepy log add'.")
+		return
+	}
+	tr.Fprintf(w, "Nights logged: %d (%d on the plan)\n", s.Nights, s.PlannedNights)
+	if s.MeanWakeDeviationMinutes != nil {
+		tr.Fprintf(w, "Mean wake up deviation: %+.0f minutes\n", *s.MeanWakeDeviationMinutes)
+	}
+	if s.MeanBedtimeDeviationMinutes != nil {
+		tr.Fprintf(w, "Mean bedtime deviation: %+.0f minutes\n", *s.MeanBedtimeDeviationMinutes)
+	}
+	if s.WithinTolerance != nil {
+		tr.Fprintf(w, "Nights within %d minutes of the plan: %.0f%%\n", s.ToleranceMinutes, *s.WithinTolerance*100)
+	}
+	if s.MeanSleepMinutes != nil {
+		tr.Fprintf(w, "Average sleep: %.1f hours (minimum functional sleep: %.1f hours, %d nights below)\n", *s.MeanSleepMinutes/60, minSleepDuration.Hours(), s.NightsBelowMinimum)
+	}
+	tr.Fprintf(w, "Sleep debt: %.1f hours\n", float64(s.SleepDebtMinutes)/60)
+	if s.SleepRegularityIndex != nil {
+		tr.Fprintf(w, "Sleep Regularity Index: %.0f\n", *s.SleepRegularityIndex)
+	}
+	if s.SocialJetLagMinutes != nil {
+		tr.Fprintf(w, "Social jet lag: %.0f minutes\n", *s.SocialJetLagMinutes)
+	}
+}
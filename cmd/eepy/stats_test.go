@@ -0,0 +1,92 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func testNight(date, bedtime, wake string) DiaryEntry {
+	d := testDate(date)
+	return DiaryEntry{
+		Date:      d,
+		Bedtime:   nightTime(d, testClock(bedtime)),
+		FinalWake: nightTime(d, testClock(wake)),
+	}
+}
+
+func TestComputeStatsAgainstPlan(t *testing.T) {
+	p := testPlan(t) // 10:00, 09:00, 08:00 from Jul 13, bedtimes 9h earlier
+	entries := []DiaryEntry{
+		testNight("2025-07-13", "01:00", "10:20"),
+		testNight("2025-07-14", "00:00", "08:00"),
+		testNight("2025-07-15", "23:00", "08:00"),
+	}
+	stats := computeStats(p, entries, 30*time.Minute)
+
+	if stats.PlannedNights != 3 {
+		t.Fatalf("Expected 3 planned nights, got %d", stats.PlannedNights)
+	}
+	if *stats.MeanWakeDeviationMinutes != -13.3 {
+		t.Errorf("Expected mean wake deviation -13.3, got %v", *stats.MeanWakeDeviationMinutes)
+	}
+	if *stats.MeanBedtimeDeviationMinutes != 0 {
+		t.Errorf("Expected mean bedtime deviation 0, got %v", *stats.MeanBedtimeDeviationMinutes)
+	}
+	if *stats.WithinTolerance != 0.667 {
+		t.Errorf("Expected 2 of 3 nights within tolerance, got %v", *stats.WithinTolerance)
+	}
+	if stats.NightsBelowMinimum != 0 || stats.SleepDebtMinutes != 60 {
+		t.Errorf("Expected 60 minutes of sleep debt and no short nights, got %d and %d", stats.SleepDebtMinutes, stats.NightsBelowMinimum)
+	}
+}
+
+func TestComputeStatsWithoutPlan(t *testing.T) {
+	stats := computeStats(nil, []DiaryEntry{testNight("2025-07-13", "23:00", "05:00")}, 30*time.Minute)
+
+	if stats.MeanWakeDeviationMinutes != nil || stats.WithinTolerance != nil {
+		t.Errorf("Expected no plan deviations without a plan")
+	}
+	if stats.NightsBelowMinimum != 1 || stats.SleepDebtMinutes != 180 {
+		t.Errorf("Expected one short night with 3h debt, got %d and %d", stats.NightsBelowMinimum, stats.SleepDebtMinutes)
+	}
+}
+
+func TestSleepRegularityIndex(t *testing.T) {
+	regular := []DiaryEntry{
+		testNight("2025-07-13", "23:00", "07:00"),
+		testNight("2025-07-14", "23:00", "07:00"),
+		testNight("2025-07-15", "23:00", "07:00"),
+	}
+	if sri := sleepRegularityIndex(regular); sri == nil || *sri != 100 {
+		t.Errorf("Expected an SRI of 100 for identical nights, got %v", sri)
+	}
+
+	shifted := []DiaryEntry{
+		testNight("2025-07-13", "23:00", "07:00"),
+		testNight("2025-07-14", "03:00", "11:00"),
+	}
+	// The nights are in the same state for 16 of the 24 hours.
+	if sri := sleepRegularityIndex(shifted); sri == nil || *sri != 33.3 {
+		t.Errorf("Expected an SRI of 33.3, got %v", sri)
+	}
+
+	if sri := sleepRegularityIndex(regular[:1]); sri != nil {
+		t.Errorf("Expected no SRI without consecutive nights, got %v", *sri)
+	}
+}
+
+func TestSocialJetLag(t *testing.T) {
+	entries := []DiaryEntry{
+		testNight("2025-07-11", "23:00", "07:00"), // Friday morning
+		testNight("2025-07-12", "01:00", "09:00"), // Saturday morning
+	}
+	if sjl := socialJetLag(entries); sjl == nil || *sjl != 120 {
+		t.Errorf("Expected 120 minutes of social jet lag, got %v", sjl)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Status describes where you are in the plan at a point in time. Bedtime and
+// NextWake are the night ahead: the next planned wake up and the bedtime
+// before it, which may already have passed.
+type Status struct {
+	State               string `json:"state"`
+	Date                string `json:"date"`
+	Day                 int    `json:"day"`
+	Days                int    `json:"days"`
+	Wake                string `json:"wake,omitempty"`
+	Bedtime             string `json:"bedtime"`
+	NextWake            string `json:"next_wake"`
+	MinutesUntilBedtime int    `json:"minutes_until_bedtime"`
+	PastBedtime         bool   `json:"past_bedtime"`
+}
+
+// Plan states reported by Status.
+const (
+	stateUpcoming = "upcoming"
+	stateActive   = "active"
+	statePaused   = "paused"
+	stateFinished = "finished"
+)
+
+// wallClock returns the wall clock time of t in loc, which plan times use.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+}
+
+// computeStatus returns the status of p at now. Once the plan has run out,
+// the target wake up time is assumed to continue.
+func computeStatus(p *Plan, now time.Time) Status {
+	now = wallClock(now, p.StartDate.Location())
+	days := p.Days()
+	status := Status{Date: now.Format(dateFormat), Days: len(days)}
+
+	if i := dayIndex(days, now); i >= 0 {
+		status.Day = days[i].Number
+		status.Wake = days[i].Wake.Format(timeFormat)
+	}
+
+	var next *Day
+	for i := range days {
+		if days[i].Wake.After(now) {
+			next = &days[i]
+			break
+		}
+	}
+	var wake, bedtime time.Time
+	if next != nil {
+		wake, bedtime = next.Wake, next.Bedtime
+	} else {
+		wake = atClock(now, p.TargetWakeTime)
+		if !wake.After(now) {
+			wake = wake.AddDate(0, 0, 1)
+		}
+		bedtime = wake.Add(-p.sleepNeed())
+	}
+	status.NextWake = wake.Format(timeFormat)
+	status.Bedtime = bedtime.Format(timeFormat)
+	status.MinutesUntilBedtime = int(bedtime.Sub(now).Minutes())
+	status.PastBedtime = !now.Before(bedtime)
+
+	switch {
+	case p.InPause(now):
+		status.State = statePaused
+	case len(days) == 0 || now.Before(days[0].Date):
+		status.State = stateUpcoming
+	case next == nil && status.Day == 0:
+		status.State = stateFinished
+	default:
+		status.State = stateActive
+	}
+	return status
+}
+
+// shortDuration formats d in hours and minutes, like 2h05m or 40m.
+func shortDuration(d time.Duration) string {
+	d = d.Abs().Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// bedtimeDistance describes how far away bedtime is, like "in 2h05m" or
+// "40m ago".
+func (s Status) bedtimeDistance() string {
+	d := time.Duration(s.MinutesUntilBedtime) * time.Minute
+	if s.PastBedtime {
+		return tr.Sprintf("%s ago", shortDuration(d))
+	}
+	return tr.Sprintf("in %s", shortDuration(d))
+}
+
+// statusLine is the status on a single line for shell prompts and status bars.
+func statusLine(s Status) string {
+	switch s.State {
+	case statePaused:
+		return tr.T("paused")
+	case stateUpcoming:
+		return tr.Sprintf("starts soon, bed %s %s", tr.clockString(s.Bedtime), s.bedtimeDistance())
+	}
+	day := tr.T("done")
+	if s.Day > 0 {
+		day = tr.Sprintf("day %d/%d", s.Day, s.Days)
+	}
+	return tr.Sprintf("%s, bed %s %s, wake %s", day, tr.clockString(s.Bedtime), s.bedtimeDistance(), tr.clockString(s.NextWake))
+}
+
+func displayStatus(w io.Writer, s Status) {
+	switch s.State {
+	case stateUpcoming:
+		tr.Fprintln(w, "Your plan has not started yet.")
+	case statePaused:
+		tr.Fprintln(w, "Your plan is paused. Resume it with 'eepy plan resume'.")
+		return
+	case stateFinished:
+		tr.Fprintln(w, "Your plan is complete. Keep to your target schedule.")
+	default:
+		tr.Fprintf(w, "Day %d of %d.\n", s.Day, s.Days)
+	}
+	if s.Wake != "" {
+		tr.Fprintf(w, "Wake up time today: %s\n", tr.clockString(s.Wake))
+	}
+	if s.PastBedtime {
+		tr.Fprintf(w, "Bedtime: %s, %s. You should be asleep.\n", tr.clockString(s.Bedtime), s.bedtimeDistance())
+	} else {
+		tr.Fprintf(w, "Bedtime: %s, %s\n", tr.clockString(s.Bedtime), s.bedtimeDistance())
+	}
+	tr.Fprintf(w, "Next wake up: %s\n", tr.clockString(s.NextWake))
+}
+
+func nowCommand(flags *pflag.FlagSet) runFunc {
+	output := flags.StringP("output", "o", "text", "Output format (text, line, json, yaml, csv or tsv)")
+	return func(a *app, args []string) error {
+		p, err := loadActivePlan()
+		if err != nil {
+			return err
+		}
+		status := computeStatus(p, timeNow())
+		if *output == "line" {
+			fmt.Fprintln(a.stdout, statusLine(status))
+			return nil
+		}
+		return writeOutput(a.stdout, *output, status, func(w io.Writer) { displayStatus(w, status) })
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeStatus(t *testing.T) {
+	p := testPlan(t)
+	at := func(date, clock string) time.Time {
+		return atClock(testDate(date), testClock(clock))
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want Status
+	}{
+		{"before the plan", at("2025-07-12", "20:00"), Status{State: stateUpcoming, Date: "2025-07-12", Days: 3, Bedtime: "01:00", NextWake: "10:00", MinutesUntilBedtime: 300}},
+		{"afternoon", at("2025-07-13", "15:00"), Status{State: stateActive, Date: "2025-07-13", Day: 1, Days: 3, Wake: "10:00", Bedtime: "00:00", NextWake: "09:00", MinutesUntilBedtime: 540}},
+		{"after midnight", at("2025-07-14", "02:00"), Status{State: stateActive, Date: "2025-07-14", Day: 2, Days: 3, Wake: "09:00", Bedtime: "00:00", NextWake: "09:00", MinutesUntilBedtime: -120, PastBedtime: true}},
+		{"last evening", at("2025-07-15", "22:30"), Status{State: stateActive, Date: "2025-07-15", Day: 3, Days: 3, Wake: "08:00", Bedtime: "23:00", NextWake: "08:00", MinutesUntilBedtime: 30}},
+		{"after the plan", at("2025-07-20", "12:00"), Status{State: stateFinished, Date: "2025-07-20", Days: 3, Bedtime: "23:00", NextWake: "08:00", MinutesUntilBedtime: 660}},
+	}
+	for _, test := range tests {
+		if got := computeStatus(p, test.now); got != test.want {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.want, got)
+		}
+	}
+
+	p.Pauses = []Pause{{Start: testDate("2025-07-14")}}
+	timeNow = func() time.Time { return at("2025-07-14", "12:00") }
+	defer func() { timeNow = time.Now }()
+	if s := computeStatus(p, timeNow()); s.State != statePaused || statusLine(s) != "paused" {
+		t.Errorf("Expected a paused status, got %+v", s)
+	}
+}
+
+func TestStatusLine(t *testing.T) {
+	s := Status{State: stateActive, Day: 2, Days: 5, Bedtime: "22:30", NextWake: "06:00", MinutesUntilBedtime: 125}
+	if got := statusLine(s); got != "day 2/5, bed 22:30 in 2h05m, wake 06:00" {
+		t.Errorf("Unexpected status line %q", got)
+	}
+	s.MinutesUntilBedtime, s.PastBedtime = -40, true
+	if got := statusLine(s); got != "day 2/5, bed 22:30 40m ago, wake 06:00" {
+		t.Errorf("Unexpected status line %q", got)
+	}
+}
+
+func TestNowCommand(t *testing.T) {
+	setConfigDir(t.TempDir())
+	timeNow = func() time.Time { return time.Date(2025, 7, 13, 20, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	if code, _, stderr := runTest(t, "", "plan", "new", "08:00", "--target", "07:00", "--adjustment", "30m", "--start-date", "2025-07-13"); code != 0 {
+		t.Fatalf("plan new failed: %s", stderr)
+	}
+	code, stdout, _ := runTest(t, "", "status", "--output", "line")
+	if code != 0 || stdout != "day 1/3, bed 22:30 in 2h30m, wake 07:30\n" {
+		t.Errorf("Expected the status alias to print one line, got %d: %q", code, stdout)
+	}
+	code, stdout, _ = runTest(t, "", "now", "-o", "json")
+	if code != 0 || !strings.Contains(stdout, `"minutes_until_bedtime": 150`) {
+		t.Errorf("Expected JSON status, got %d: %s", code, stdout)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// The terminal size assumed when it is unknown.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// terminalFile returns w as a file if it is a terminal.
+func terminalFile(w io.Writer) (*os.File, bool) {
+	f, ok := w.(*os.File)
+	return f, ok && isTerminalFile(f)
+}
+
+// stty runs stty with args on the terminal f and returns its output.
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// terminalSize returns the width and height of the terminal w writes to,
+// from COLUMNS and LINES or by asking stty, falling back to the defaults.
+func terminalSize(w io.Writer, getenv func(string) string) (width, height int) {
+	width, height = defaultWidth, defaultHeight
+	if f, ok := terminalFile(w); ok {
+		if out, err := stty(f, "size"); err == nil {
+			var rows, cols int
+			if _, err := fmt.Sscan(out, &rows, &cols); err == nil && rows > 0 && cols > 0 {
+				width, height = cols, rows
+			}
+		}
+	}
+	if n, err := strconv.Atoi(getenv("COLUMNS")); err == nil && n > 0 {
+		width = n
+	}
+	if n, err := strconv.Atoi(getenv("LINES")); err == nil && n > 0 {
+		height = n
+	}
+	return width, height
+}
+
+// terminalWidth returns the width of the terminal w writes to.
+func terminalWidth(w io.Writer, getenv func(string) string) int {
+	width, _ := terminalSize(w, getenv)
+	return width
+}
+
+// rawMode switches the terminal f to raw mode, so keys are read as they
+// are pressed and not echoed, and returns a function that restores it.
+func rawMode(f *os.File) (restore func(), err error) {
+	state, err := stty(f, "-g")
+	if err != nil {
+		return nil, tr.Errorf("error reading terminal settings: %w", err)
+	}
+	if _, err := stty(f, "raw", "-echo"); err != nil {
+		return nil, tr.Errorf("error switching terminal to raw mode: %w", err)
+	}
+	return func() { stty(f, state) }, nil
+}
+
+// useColor reports whether output to w may be coloured: w must be a
+// terminal and NO_COLOR must not be set.
+func useColor(w io.Writer, getenv func(string) string) bool {
+	_, ok := terminalFile(w)
+	return ok && getenv("NO_COLOR") == ""
+}
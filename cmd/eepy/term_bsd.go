@@ -0,0 +1,15 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import "syscall"
+
+// ioctlGetTermios is the ioctl request that reads the settings of a
+// terminal.
+const ioctlGetTermios = syscall.TIOCGETA
@@ -0,0 +1,89 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// runCommand runs a program on this machine and returns its output. Tests
+// replace it.
+var runCommand = func(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return "", tr.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// inTermux reports whether eepy runs in Termux on the phone itself.
+func inTermux(getenv func(string) string) bool {
+	return getenv("TERMUX_VERSION") != "" || strings.HasPrefix(getenv("PREFIX"), "/data/data/com.termux/")
+}
+
+// defaultAlarmBackend is the alarm backend used when none is chosen: Termux
+// on the phone and ADB elsewhere.
+func defaultAlarmBackend(getenv func(string) string) string {
+	if inTermux(getenv) {
+		return "termux"
+	}
+	return "adb"
+}
+
+// notifier is implemented by backends that can post a notification on the
+// device.
+type notifier interface {
+	Notify(title, text string) error
+}
+
+// termuxBackend sets alarms on the phone Termux runs on, with the same
+// intents as over ADB but started locally.
+type termuxBackend struct {
+	*androidBackend
+	run func(name string, args ...string) (string, error)
+}
+
+// newTermuxBackend returns a backend that starts intents with am, which is
+// termux-am or the older am of Termux, through run.
+func newTermuxBackend(run func(name string, args ...string) (string, error), am string) *termuxBackend {
+	return &termuxBackend{
+		androidBackend: &androidBackend{
+			name:   "Termux",
+			target: alarmTarget{Backend: "termux"},
+			am:     am,
+			shell: func(command string) (string, error) {
+				return run("sh", "-c", command)
+			},
+		},
+		run: run,
+	}
+}
+
+// newTermuxBackends opens the Termux backend. Devices are ignored, since
+// Termux only reaches the phone it runs on.
+func newTermuxBackends(a *app, devices []string) ([]alarmBackend, error) {
+	am := "termux-am"
+	if _, err := exec.LookPath(am); err != nil {
+		am = "am"
+	}
+	return []alarmBackend{newTermuxBackend(runCommand, am)}, nil
+}
+
+// List fails, since apps are not allowed to read the alarms of the clock
+// app from dumpsys.
+func (b *termuxBackend) List() ([]Alarm, error) {
+	return nil, tr.Errorf("the alarms of the clock app cannot be read from Termux: %w", errors.ErrUnsupported)
+}
+
+// Notify posts a notification with termux-notification from the Termux:API
+// add-on. It replaces the one posted before.
+func (b *termuxBackend) Notify(title, text string) error {
+	_, err := b.run("termux-notification", "--id", "eepy", "--title", title, "--content", text)
+	return err
+}
@@ -0,0 +1,71 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInTermux(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	tests := []struct {
+		vars map[string]string
+		want string
+	}{
+		{map[string]string{}, "adb"},
+		{map[string]string{"TERMUX_VERSION": "0.118.1"}, "termux"},
+		{map[string]string{"PREFIX": "/data/data/com.termux/files/usr"}, "termux"},
+		{map[string]string{"PREFIX": "/usr/local"}, "adb"},
+	}
+	for _, test := range tests {
+		if got := defaultAlarmBackend(env(test.vars)); got != test.want {
+			t.Errorf("%v: expected %s, got %s", test.vars, test.want, got)
+		}
+	}
+}
+
+func TestTermuxBackend(t *testing.T) {
+	setConfigDir(t.TempDir())
+	t.Setenv("TERMUX_VERSION", "0.118.1")
+	var commands []string
+	original := runCommand
+	defer func() { runCommand = original }()
+	runCommand = func(name string, args ...string) (string, error) {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return "Starting: Intent { ... }", nil
+	}
+	timeNow = func() time.Time { return time.Date(2025, 7, 12, 20, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:00", "--adjustment", "1h", "--start-date", "2025-07-13")
+
+	code, stdout, stderr := runTest(t, "", "alarms", "sync", "--notify")
+	if code != 0 || len(commands) != 4 || !strings.Contains(stdout, "Setting alarms via Termux...") {
+		t.Fatalf("Expected 3 alarms and a notification, got %d, %q:\n%s%s", code, commands, stdout, stderr)
+	}
+	if !strings.HasPrefix(commands[0], "sh -c am start -W -a android.intent.action.SET_ALARM") {
+		t.Errorf("Expected the alarm intent to be started locally, got %s", commands[0])
+	}
+	if want := "termux-notification --id eepy --title Sleep plan alarms --content 3 alarms set, 0 dismissed, 0 failed"; commands[3] != want {
+		t.Errorf("Unexpected notification:\n%s\nexpected:\n%s", commands[3], want)
+	}
+
+	commands = nil
+	if code, stdout, _ := runTest(t, "", "alarms", "sync"); code != 0 || len(commands) != 0 {
+		t.Errorf("Expected syncing again to change nothing, got %d, %q:\n%s", code, commands, stdout)
+	}
+	if code, _, stderr := runTest(t, "", "alarms", "verify"); code != 1 || !strings.Contains(stderr, "cannot be read from Termux") {
+		t.Errorf("Expected verify to explain that Termux cannot read alarms, got %d: %s", code, stderr)
+	}
+	// The alarms repeat weekly, which the clock app cannot delete.
+	if code, stdout, _ := runTest(t, "", "alarms", "clear"); code != 0 || len(commands) != 3 || !strings.Contains(commands[0], "DISMISS_ALARM") || !strings.Contains(stdout, `Cancel "Sleep Adjustment Wake Up: Sun, Jul 13" on the device yourself`) {
+		t.Errorf("Expected clear to dismiss the alarms locally and leave them to the user, got %d, %q:\n%s", code, commands, stdout)
+	}
+}
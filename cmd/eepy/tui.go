@@ -0,0 +1,487 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/pflag"
+)
+
+// Escape sequences that switch to the alternate screen with the cursor
+// hidden and back.
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	styleSelect = "\x1b[7m"
+)
+
+// Panes of the TUI, switched with tab.
+const (
+	paneSchedule = iota
+	paneHistory
+	paneDiary
+	paneCount
+)
+
+var paneNames = [paneCount]string{"Schedule", "History", "Diary"}
+
+// Plan parameters edited in the schedule pane, in the order they are shown.
+const (
+	fieldWake = iota
+	fieldTarget
+	fieldAdjustment
+	fieldStartDate
+	fieldSleepNeed
+	fieldCount
+)
+
+// How far one key press moves a parameter.
+const (
+	clockStep      = 15 * time.Minute
+	adjustmentStep = 5 * time.Minute
+	sleepNeedStep  = 15 * time.Minute
+	maxAdjustment  = 3 * time.Hour
+	maxSleepNeed   = 16 * time.Hour
+)
+
+// tui is the full-screen plan editor. It handles key names and renders
+// lines, so tests can drive it without a terminal.
+type tui struct {
+	wake       time.Time
+	target     time.Time
+	adjustment time.Duration
+	sleepNeed  time.Duration
+	startDate  time.Time
+
+	field  int
+	pane   int
+	scroll int
+	page   int
+
+	history []string
+	diary   []string
+	view    termView
+	// active is the active plan the editor started from, if any.
+	active *Plan
+
+	done bool
+	save bool
+}
+
+// newTUI starts from the active plan, or from the settings and wakeArg if
+// there is none. A wakeArg also replaces the wake up time of the plan.
+func (a *app) newTUI(wakeArg string) (*tui, error) {
+	t := &tui{view: termView{color: useColor(a.stdout, a.env), now: wallClock(timeNow(), time.UTC)}}
+	p, err := loadPlan()
+	switch {
+	case err == nil:
+		t.wake, t.target, t.adjustment = p.InitialWakeTime, p.TargetWakeTime, p.Adjustment
+		t.sleepNeed, t.startDate, t.active = p.sleepNeed(), p.StartDate, p
+	case errors.Is(err, os.ErrNotExist):
+		if wakeArg == "" {
+			return nil, tr.Errorf("tui needs your current wake up time when there is no active plan")
+		}
+		if err := t.loadSettings(a); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, tr.Errorf("error loading plan: %w", err)
+	}
+	if wakeArg != "" {
+		if t.wake, err = parseClock(wakeArg); err != nil {
+			return nil, tr.Errorf("error parsing wake-time: %w", err)
+		}
+	}
+
+	plans, err := listArchivedPlans()
+	if err != nil {
+		return nil, err
+	}
+	for _, archived := range slices.Backward(plans) {
+		p, err := loadPlanFile(archived.path)
+		if err != nil {
+			return nil, err
+		}
+		t.history = append(t.history, archived.describe(p))
+	}
+	if len(t.history) == 0 {
+		t.history = []string{tr.T("No replaced plans yet.")}
+	}
+
+	entries, err := loadDiary()
+	if err != nil {
+		return nil, err
+	}
+	var diary bytes.Buffer
+	for _, e := range slices.Backward(entries) {
+		printDiaryEntry(&diary, e)
+	}
+	t.diary = strings.Split(strings.TrimSuffix(diary.String(), "\n"), "\n")
+	if len(entries) == 0 {
+		t.diary = []string{tr.T("No diary entries found. Record one with 'eepy log add'.")}
+	}
+	return t, nil
+}
+
+// loadSettings takes the parameters of a new plan from the settings.
+func (t *tui) loadSettings(a *app) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return tr.Errorf("error loading config: %w", err)
+	}
+	value := func(key string) string {
+		v, _ := lookupSetting(key, a.getenv, cfg)
+		return v
+	}
+	if t.target, err = parseClock(value("target")); err != nil {
+		return tr.Errorf("error parsing target: %w", err)
+	}
+	if t.adjustment, err = time.ParseDuration(value("adjustment")); err != nil {
+		return tr.Errorf("error parsing adjustment: %w", err)
+	}
+	if t.sleepNeed, err = time.ParseDuration(value("sleep-need")); err != nil {
+		return tr.Errorf("error parsing sleep-need: %w", err)
+	}
+	t.startDate, err = parseDateFlag("", time.UTC)
+	return err
+}
+
+// keepsEdits reports whether saving replans the active plan, keeping its
+// edits and pauses. They go by date, so that is only while the plan starts
+// on the same day.
+func (t *tui) keepsEdits() bool {
+	return t.active != nil && t.startDate.Equal(t.active.StartDate)
+}
+
+// draft is the plan as it would be saved now.
+func (t *tui) draft() *Plan {
+	if !t.keepsEdits() {
+		return buildPlan(t.wake, t.target, t.adjustment, t.sleepNeed, t.startDate)
+	}
+	p := *t.active
+	p.InitialWakeTime, p.TargetWakeTime, p.Adjustment, p.SleepNeed = t.wake, t.target, t.adjustment, t.sleepNeed
+	p.Schedule = generateSchedule(p.InitialWakeTime, p.TargetWakeTime, p.Adjustment, p.StartDate)
+	return &p
+}
+
+// change moves the selected parameter one step up or down.
+func (t *tui) change(dir int) {
+	d := time.Duration(dir)
+	switch t.field {
+	case fieldWake:
+		t.wake = clockOf(t.wake.Add(d * clockStep))
+	case fieldTarget:
+		t.target = clockOf(t.target.Add(d * clockStep))
+	case fieldAdjustment:
+		t.adjustment = min(max(t.adjustment+d*adjustmentStep, adjustmentStep), maxAdjustment)
+	case fieldStartDate:
+		t.startDate = t.startDate.AddDate(0, 0, dir)
+	case fieldSleepNeed:
+		t.sleepNeed = min(max(t.sleepNeed+d*sleepNeedStep, minSleepDuration), maxSleepNeed)
+	}
+}
+
+// handle applies a key press, named as by parseKeys.
+func (t *tui) handle(key string) {
+	switch key {
+	case "q", "esc", "ctrl-c":
+		t.done = true
+	case "s":
+		t.done, t.save = true, true
+	case "tab":
+		t.pane, t.scroll = (t.pane+1)%paneCount, 0
+	case "shift-tab":
+		t.pane, t.scroll = (t.pane+paneCount-1)%paneCount, 0
+	case "1", "2", "3":
+		t.pane, t.scroll = int(key[0]-'1'), 0
+	case "up", "k":
+		if t.pane == paneSchedule {
+			t.field = max(t.field-1, 0)
+		} else {
+			t.scroll--
+		}
+	case "down", "j":
+		if t.pane == paneSchedule {
+			t.field = min(t.field+1, fieldCount-1)
+		} else {
+			t.scroll++
+		}
+	case "pgup":
+		t.scroll -= max(t.page, 1)
+	case "pgdn":
+		t.scroll += max(t.page, 1)
+	case "left", "h", "-":
+		if t.pane == paneSchedule {
+			t.change(-1)
+		}
+	case "right", "l", "+":
+		if t.pane == paneSchedule {
+			t.change(1)
+		}
+	}
+}
+
+// fit cuts s to width columns. Lines with escape sequences are already
+// drawn to fit and are left alone.
+func fit(s string, width int) string {
+	if strings.Contains(s, "\x1b") || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
+
+// render draws the screen as lines for a terminal of the given size.
+func (t *tui) render(width, height int) []string {
+	var tabs []string
+	for i, name := range paneNames {
+		if i == t.pane {
+			tabs = append(tabs, "["+tr.T(name)+"]")
+		} else {
+			tabs = append(tabs, " "+tr.T(name)+" ")
+		}
+	}
+	title := tr.T("eepy plan editor")
+	tabLine := strings.Join(tabs, "")
+	gap := max(width-utf8.RuneCountInString(title)-utf8.RuneCountInString(tabLine), 1)
+	lines := []string{title + strings.Repeat(" ", gap) + tabLine, strings.Repeat("─", width)}
+
+	var fixed, body []string
+	switch t.pane {
+	case paneSchedule:
+		fixed, body = t.schedule(width)
+	case paneHistory:
+		body = t.history
+	case paneDiary:
+		body = t.diary
+	}
+
+	help := tr.T("↑↓ scroll  tab switch view  s save  q cancel")
+	if t.pane == paneSchedule {
+		help = tr.T("↑↓ select  ←→ change  PgUp/PgDn scroll  tab switch view  s save  q cancel")
+	}
+	footer := []string{strings.Repeat("─", width), help}
+	switch {
+	case t.keepsEdits():
+		footer = slices.Insert(footer, 1, tr.T("Saving replans the active plan and keeps its edits; 'eepy plan undo' brings it back."))
+	case t.active != nil:
+		footer = slices.Insert(footer, 1, tr.T("Saving replaces the active plan; 'eepy plan undo' brings it back."))
+	}
+
+	t.page = max(height-len(lines)-len(fixed)-len(footer), 1)
+	t.scroll = max(min(t.scroll, len(body)-t.page), 0)
+	body = body[t.scroll:min(t.scroll+t.page, len(body))]
+
+	lines = append(lines, fixed...)
+	lines = append(lines, body...)
+	for len(lines) < height-len(footer) {
+		lines = append(lines, "")
+	}
+	// On very short terminals the parameters do not fit either.
+	lines = append(lines[:max(height-len(footer), 0)], footer...)
+	for i := range lines {
+		lines[i] = fit(lines[i], width)
+	}
+	return lines
+}
+
+// schedule draws the parameters and the progress of the draft plan, which
+// stay in place, and its timeline, which scrolls.
+func (t *tui) schedule(width int) (fixed, body []string) {
+	fields := [fieldCount][2]string{
+		fieldWake:       {tr.T("Current wake up"), tr.clock(t.wake)},
+		fieldTarget:     {tr.T("Target wake up"), tr.clock(t.target)},
+		fieldAdjustment: {tr.T("Adjustment per day"), shortDuration(t.adjustment)},
+		fieldStartDate:  {tr.T("Start date"), tr.date(t.startDate)},
+		fieldSleepNeed:  {tr.T("Sleep need"), shortDuration(t.sleepNeed)},
+	}
+	labelWidth := 0
+	for _, f := range fields {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(f[0]))
+	}
+	for i, f := range fields {
+		line := fmt.Sprintf("  %s  %s", padRight(f[0], labelWidth), f[1])
+		if i == t.field {
+			line = "▸" + line[1:]
+			line = t.view.paint(styleSelect, line)
+		}
+		fixed = append(fixed, line)
+	}
+
+	p := t.draft()
+	days := p.Days()
+	v := t.view
+	v.width = width
+	var out bytes.Buffer
+	v.progress(&out, p, days)
+	fmt.Fprintln(&out)
+	v.timeline(&out, days)
+	rest := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	// The progress bar, a blank line and the hour axis stay in place.
+	fixed = append(fixed, "")
+	fixed = append(fixed, rest[:3]...)
+	return fixed, rest[3:]
+}
+
+// draw writes the screen to out, overwriting the previous one.
+func (t *tui) draw(out io.Writer, width, height int) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range t.render(width, height) {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line + "\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	io.WriteString(out, b.String())
+}
+
+// escapeKeys names the escape sequences of the keys the TUI uses.
+var escapeKeys = []struct{ seq, name string }{
+	{"\x1b[A", "up"}, {"\x1bOA", "up"},
+	{"\x1b[B", "down"}, {"\x1bOB", "down"},
+	{"\x1b[C", "right"}, {"\x1bOC", "right"},
+	{"\x1b[D", "left"}, {"\x1bOD", "left"},
+	{"\x1b[5~", "pgup"}, {"\x1b[6~", "pgdn"},
+	{"\x1b[Z", "shift-tab"},
+}
+
+// parseKeys splits what one read from a raw terminal returned into key
+// names. Other escape sequences are dropped, and an escape on its own is
+// the escape key.
+func parseKeys(b []byte) []string {
+	var keys []string
+next:
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			for _, k := range escapeKeys {
+				if bytes.HasPrefix(b, []byte(k.seq)) {
+					keys, b = append(keys, k.name), b[len(k.seq):]
+					continue next
+				}
+			}
+			if len(b) > 2 && (b[1] == '[' || b[1] == 'O') {
+				end := bytes.IndexFunc(b[2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+				if end < 0 {
+					return keys
+				}
+				b = b[end+3:]
+				continue
+			}
+			keys, b = append(keys, "esc"), b[1:]
+			continue
+		}
+		switch b[0] {
+		case '\r', '\n':
+			keys, b = append(keys, "enter"), b[1:]
+		case '\t':
+			keys, b = append(keys, "tab"), b[1:]
+		case 0x03:
+			keys, b = append(keys, "ctrl-c"), b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys, b = append(keys, string(r)), b[size:]
+		}
+	}
+	return keys
+}
+
+// run draws the screen and handles keys from in until the user saves or
+// cancels. The end of input cancels.
+func (t *tui) run(in io.Reader, out io.Writer, size func() (int, int)) error {
+	buf := make([]byte, 64)
+	for !t.done {
+		width, height := size()
+		t.draw(out, width, height)
+		n, err := in.Read(buf)
+		for _, key := range parseKeys(buf[:n]) {
+			if t.handle(key); t.done {
+				break
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			t.done = true
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish saves the draft plan if the user asked for it. A plan that starts
+// on the day of the active plan replans it; one that starts on another day
+// replaces it, after confirming that its edits and pauses are dropped.
+func (t *tui) finish(a *app) error {
+	if !t.save {
+		tr.Fprintln(a.stdout, "Operation cancelled.")
+		return nil
+	}
+	p := t.draft()
+	if t.keepsEdits() {
+		if err := ensureRevisionBaseline(t.active); err != nil {
+			return tr.Errorf("error recording existing plan: %w", err)
+		}
+		if err := replan(p); err != nil {
+			return err
+		}
+		displayPlan(a.stdout, p)
+		return nil
+	}
+	if t.active != nil && (len(t.active.Overrides) > 0 || len(t.active.Pauses) > 0) {
+		ok, err := a.confirm(tr.T("The new start date drops the edits and pauses of the active plan. Save anyway?"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			tr.Fprintln(a.stdout, "Operation cancelled.")
+			return nil
+		}
+	}
+	if err := replacePlan(p); err != nil {
+		return err
+	}
+	displayPlan(a.stdout, p)
+	a.dismissStaleAlarms(func(c CreatedAlarm) bool { return c.Plan != p.id() })
+	return nil
+}
+
+func tuiCommand(flags *pflag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if len(args) > 1 {
+			return tr.Errorf("tui takes at most one wake up time (HH:MM)")
+		}
+		in, ok := a.stdin.(*os.File)
+		if _, isOut := terminalFile(a.stdout); !ok || !isTerminal(in) || !isOut {
+			return tr.Errorf("tui needs a terminal; use 'eepy plan new' in scripts")
+		}
+		t, err := a.newTUI(strings.Join(args, ""))
+		if err != nil {
+			return err
+		}
+		restore, err := rawMode(in)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(a.stdout, enterScreen)
+		err = t.run(in, a.stdout, func() (int, int) { return terminalSize(a.stdout, a.env) })
+		fmt.Fprint(a.stdout, leaveScreen)
+		restore()
+		if err != nil {
+			return err
+		}
+		return t.finish(a)
+	}
+}
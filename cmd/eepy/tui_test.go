@@ -0,0 +1,177 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("\x1b[A\x1bOBjs\x1b[1;5H\x1b[5~\t\x1b[Z\r\x03\x1bø"))
+	want := []string{"up", "down", "j", "s", "pgup", "tab", "shift-tab", "enter", "ctrl-c", "esc", "ø"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestTUIEditsPlan(t *testing.T) {
+	setConfigDir(t.TempDir())
+	timeNow = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:00", "--adjustment", "1h", "--start-date", "2025-01-01")
+	runTest(t, "", "log", "add", "--date", "2025-01-01", "--bedtime", "23:00", "--wake", "08:00")
+
+	var stdout bytes.Buffer
+	a := &app{stdout: &stdout}
+	ui, err := a.newTUI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Target half an hour earlier, adjustment 10 minutes shorter, start a
+	// day later, then save.
+	keys := "j\x1b[D\x1b[Dj--j+s"
+	if err := ui.run(strings.NewReader(keys), &bytes.Buffer{}, func() (int, int) { return 80, 24 }); err != nil {
+		t.Fatal(err)
+	}
+	if !ui.save || ui.target.Format(timeFormat) != "05:30" || ui.adjustment != 50*time.Minute || !sameDate(ui.startDate, testDate("2025-01-02")) {
+		t.Fatalf("Unexpected editor state: save %v, target %s, adjustment %s, start %s", ui.save, ui.target.Format(timeFormat), ui.adjustment, ui.startDate)
+	}
+	if err := ui.finish(a); err != nil {
+		t.Fatal(err)
+	}
+	p, err := loadActivePlan()
+	if err != nil || p.TargetWakeTime.Format(timeFormat) != "05:30" || !strings.Contains(stdout.String(), "Thu, Jan 2 (Day 1):") {
+		t.Errorf("Expected the edited plan to be saved, got %v:\n%s", err, stdout.String())
+	}
+	if plans, _ := listArchivedPlans(); len(plans) != 1 {
+		t.Errorf("Expected the replaced plan in the history, got %d", len(plans))
+	}
+
+	ui, err = a.newTUI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.handle("tab")
+	if screen := strings.Join(ui.render(80, 24), "\n"); !strings.Contains(screen, "#1 Wed, Jan 1 2025: 08:00 to 06:00") {
+		t.Errorf("Expected the history pane to list the replaced plan, got:\n%s", screen)
+	}
+	ui.handle("tab")
+	if screen := strings.Join(ui.render(80, 24), "\n"); !strings.Contains(screen, "#1 Wed, Jan 1 (Day 1):") {
+		t.Errorf("Expected the diary pane to list the diary, got:\n%s", screen)
+	}
+}
+
+func TestTUIKeepsEdits(t *testing.T) {
+	setConfigDir(t.TempDir())
+	timeNow = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	runTest(t, "", "plan", "new", "08:00", "--target", "06:00", "--adjustment", "1h", "--start-date", "2025-01-01")
+	runTest(t, "", "plan", "edit", "2025-01-02", "--note", "Dentist")
+
+	var stdout bytes.Buffer
+	a := &app{stdout: &stdout}
+	ui, err := a.newTUI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Target half an hour earlier, then save.
+	if err := ui.run(strings.NewReader("j\x1b[D\x1b[Ds"), &bytes.Buffer{}, func() (int, int) { return 80, 24 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.finish(a); err != nil {
+		t.Fatal(err)
+	}
+	p, err := loadActivePlan()
+	if err != nil || p.TargetWakeTime.Format(timeFormat) != "05:30" || p.id() != ui.active.id() || len(p.Overrides) != 1 {
+		t.Errorf("Expected the plan to be replanned with its edit, got %v, %+v", err, p)
+	}
+	if plans, _ := listArchivedPlans(); len(plans) != 0 {
+		t.Errorf("Expected nothing in the history, got %d plans", len(plans))
+	}
+
+	// A later start date drops the edit, which needs confirming.
+	ui, err = a.newTUI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.run(strings.NewReader("jjj+s"), &bytes.Buffer{}, func() (int, int) { return 80, 24 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.finish(a); err == nil {
+		t.Error("Expected dropping the edits to need confirming")
+	}
+	if p, _ := loadActivePlan(); len(p.Overrides) != 1 {
+		t.Errorf("Expected the plan to be left alone without confirming, got %+v", p.Overrides)
+	}
+	a.assumeYes = true
+	if err := ui.finish(a); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := loadActivePlan(); len(p.Overrides) != 0 || !sameDate(p.StartDate, testDate("2025-01-02")) {
+		t.Errorf("Expected a new plan without the edit, got %+v", p)
+	}
+}
+
+func TestTUICancel(t *testing.T) {
+	setConfigDir(t.TempDir())
+	runTest(t, "", "plan", "new", "08:00", "--start-date", "2025-01-01")
+
+	var stdout bytes.Buffer
+	a := &app{stdout: &stdout}
+	ui, err := a.newTUI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.run(strings.NewReader("jj+++\x1b"), &bytes.Buffer{}, func() (int, int) { return 80, 24 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.finish(a); err != nil || !strings.Contains(stdout.String(), "Operation cancelled.") {
+		t.Errorf("Expected escape to cancel, got %v: %s", err, stdout.String())
+	}
+	if plans, _ := listArchivedPlans(); len(plans) != 0 {
+		t.Errorf("Expected cancelling to leave the plan alone, got %d replaced plans", len(plans))
+	}
+}
+
+func TestTUINewPlan(t *testing.T) {
+	setConfigDir(t.TempDir())
+	a := &app{getenv: func(key string) string { return map[string]string{"EEPY_TARGET": "06:30"}[key] }}
+	if _, err := a.newTUI(""); err == nil {
+		t.Error("Expected the editor to need a wake up time without a plan")
+	}
+	ui, err := a.newTUI("9am")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ui.wake.Format(timeFormat) != "09:00" || ui.target.Format(timeFormat) != "06:30" || ui.sleepNeed != idealSleepDuration {
+		t.Errorf("Expected the settings to shape a new plan, got wake %s, target %s, sleep need %s", ui.wake.Format(timeFormat), ui.target.Format(timeFormat), ui.sleepNeed)
+	}
+
+	for _, size := range [][2]int{{40, 12}, {80, 24}, {120, 40}} {
+		lines := ui.render(size[0], size[1])
+		if len(lines) != size[1] {
+			t.Errorf("Expected %d lines, got %d", size[1], len(lines))
+		}
+		for _, line := range lines {
+			if utf8.RuneCountInString(line) > size[0] {
+				t.Errorf("Line wider than %d columns: %q", size[0], line)
+			}
+		}
+	}
+}
+
+func TestTUINeedsTerminal(t *testing.T) {
+	setConfigDir(t.TempDir())
+	if code, _, stderr := runTest(t, "", "tui", "08:00"); code != 1 || !strings.Contains(stderr, "needs a terminal") {
+		t.Errorf("Expected the editor to refuse to run without a terminal, got %d: %s", code, stderr)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Terminal styles used by the calendar and timeline views.
+const (
+	styleReset    = "\x1b[0m"
+	styleToday    = "\x1b[1;36m"
+	stylePast     = "\x1b[2m"
+	styleProgress = "\x1b[32m"
+)
+
+// termView draws a plan for a terminal of the given width. Today is
+// marked and, with colour, highlighted; past days are dimmed.
+type termView struct {
+	width int
+	color bool
+	now   time.Time
+}
+
+func newTermView(a *app, p *Plan) termView {
+	return termView{
+		width: terminalWidth(a.stdout, a.env),
+		color: useColor(a.stdout, a.env),
+		now:   wallClock(timeNow(), p.StartDate.Location()),
+	}
+}
+
+func (v termView) paint(style, s string) string {
+	if !v.color || style == "" {
+		return s
+	}
+	return style + s + styleReset
+}
+
+func (v termView) dayStyle(d Day) string {
+	switch {
+	case sameDate(d.Date, v.now):
+		return styleToday
+	case d.Date.Before(dateOf(v.now)):
+		return stylePast
+	}
+	return ""
+}
+
+// padLeft right-aligns s in n columns.
+func padLeft(s string, n int) string {
+	return strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0)) + s
+}
+
+// padRight left-aligns s in n columns.
+func padRight(s string, n int) string {
+	return s + strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0))
+}
+
+// progressBar draws percent of width cells, using eighth blocks for the
+// partly filled cell.
+func progressBar(percent float64, width int) (filled, empty string) {
+	eighths := int(percent / 100 * float64(width*8))
+	eighths = min(max(eighths, 0), width*8)
+	filled = strings.Repeat("█", eighths/8)
+	cells := eighths / 8
+	if eighths%8 > 0 {
+		filled += string([]rune("▏▎▍▌▋▊▉")[eighths%8-1])
+		cells++
+	}
+	return filled, strings.Repeat("░", width-cells)
+}
+
+func (v termView) progress(w io.Writer, p *Plan, days []Day) {
+	percent := planProgress(p, days, v.now)
+	label := tr.Sprintf("Progress toward %s", tr.clock(p.TargetWakeTime))
+	width := min(max(v.width-utf8.RuneCountInString(label)-7, 10), 40)
+	filled, empty := progressBar(percent, width)
+	fmt.Fprintf(w, "%s %s%s %3.0f%%\n", label, v.paint(styleProgress, filled), empty, percent)
+}
+
+// calendar draws the days as a month calendar with the planned wake up
+// time under each date. The times are left out if they do not fit.
+func (v termView) calendar(w io.Writer, days []Day) {
+	byDate := make(map[string]Day, len(days))
+	cell := 4
+	for _, d := range days {
+		byDate[d.Date.Format(dateFormat)] = d
+		cell = max(cell, utf8.RuneCountInString(tr.clock(d.Wake))+2)
+	}
+	for _, name := range tr.weekdays {
+		cell = max(cell, utf8.RuneCountInString(name)+2)
+	}
+	showTimes := 7*cell <= v.width
+	if !showTimes {
+		cell = 5
+	}
+
+	first, last := days[0].Date, days[len(days)-1].Date
+	weekStart := first.AddDate(0, 0, -((int(first.Weekday()) - int(tr.weekStart) + 7) % 7))
+	var header strings.Builder
+	for i := range 7 {
+		name := tr.weekdays[(int(tr.weekStart)+i)%7]
+		if !showTimes {
+			name = string([]rune(name)[:min(utf8.RuneCountInString(name), 3)])
+		}
+		header.WriteString(padLeft(name, cell))
+	}
+
+	for week := weekStart; !week.After(last); week = week.AddDate(0, 0, 7) {
+		for i := range 7 {
+			date := week.AddDate(0, 0, i)
+			if date.Before(first) || date.After(last) || (date.Day() != 1 && !date.Equal(first)) {
+				continue
+			}
+			if !date.Equal(first) {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s %d\n%s\n", tr.months[date.Month()-1], date.Year(), header.String())
+			break
+		}
+		var numbers, times strings.Builder
+		for i := range 7 {
+			d, ok := byDate[week.AddDate(0, 0, i).Format(dateFormat)]
+			if !ok {
+				numbers.WriteString(strings.Repeat(" ", cell))
+				times.WriteString(strings.Repeat(" ", cell))
+				continue
+			}
+			number := fmt.Sprint(d.Date.Day())
+			if sameDate(d.Date, v.now) {
+				number = "[" + number + "]"
+			}
+			numbers.WriteString(v.paint(v.dayStyle(d), padLeft(number, cell)))
+			times.WriteString(v.paint(v.dayStyle(d), padLeft(tr.clock(d.Wake), cell)))
+		}
+		fmt.Fprintln(w, strings.TrimRight(numbers.String(), " "))
+		if showTimes {
+			fmt.Fprintln(w, strings.TrimRight(times.String(), " "))
+		}
+	}
+}
+
+// asleep reports whether minute m of the day falls between bedtime and
+// wake up.
+func asleep(m float64, bedtime, wake time.Time) bool {
+	if wake.Sub(bedtime) >= 24*time.Hour {
+		return true
+	}
+	b := float64(bedtime.Hour()*60 + bedtime.Minute())
+	e := float64(wake.Hour()*60 + wake.Minute())
+	if b <= e {
+		return b <= m && m < e
+	}
+	return m >= b || m < e
+}
+
+// sleepBar draws the night before wake on a 24-hour line of width cells,
+// like the sleep blocks of the HTML report. Each cell is split in halves.
+func sleepBar(bedtime, wake time.Time, width int) string {
+	var b strings.Builder
+	span := 1440 / float64(width)
+	for i := range width {
+		start := float64(i) * span
+		left := asleep(start+span/4, bedtime, wake)
+		right := asleep(start+3*span/4, bedtime, wake)
+		switch {
+		case left && right:
+			b.WriteString("█")
+		case left:
+			b.WriteString("▌")
+		case right:
+			b.WriteString("▐")
+		default:
+			b.WriteString("░")
+		}
+	}
+	return b.String()
+}
+
+// timeline draws a 24-hour line per day with the night's sleep filled in.
+// The line is a multiple of 24 cells so that hours line up, or 12 cells on
+// very narrow terminals. The times are left out if they do not fit.
+func (v termView) timeline(w io.Writer, days []Day) {
+	labelWidth, timesWidth := 0, 0
+	for _, d := range days {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(tr.day(d.Date)))
+		timesWidth = max(timesWidth, utf8.RuneCountInString(tr.clock(d.Bedtime)+"–"+tr.clock(d.Wake)))
+	}
+	width := v.width - labelWidth - 4
+	showTimes := width-timesWidth-2 >= 24
+	if showTimes {
+		width -= timesWidth + 2
+	}
+	if width = min(width, 96); width >= 24 {
+		width -= width % 24
+	} else {
+		width = 12
+	}
+
+	hours := []string{"0", "6", "12", "18"}
+	if tr.clock12 {
+		hours = []string{"12a", "6a", "12p", "6p"}
+	}
+	axis := []rune(strings.Repeat(" ", width))
+	for i, hour := range hours {
+		copy(axis[i*width/4:], []rune(hour))
+	}
+	fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", labelWidth+4), strings.TrimRight(string(axis), " "))
+
+	for _, d := range days {
+		marker := " "
+		if sameDate(d.Date, v.now) {
+			marker = "▸"
+		}
+		line := fmt.Sprintf("%s %s  %s", marker, padRight(tr.day(d.Date), labelWidth), sleepBar(d.Bedtime, d.Wake, width))
+		if showTimes {
+			line += "  " + tr.clock(d.Bedtime) + "–" + tr.clock(d.Wake)
+		}
+		fmt.Fprintln(w, v.paint(v.dayStyle(d), line))
+	}
+}
+
+// writeView prints p as a calendar or a timeline, below a progress bar.
+func (v termView) writeView(w io.Writer, view string, p *Plan) {
+	days := p.Days()
+	v.progress(w, p, days)
+	fmt.Fprintln(w)
+	if view == "calendar" {
+		v.calendar(w, days)
+	} else {
+		v.timeline(w, days)
+	}
+}
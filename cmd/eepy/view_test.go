@@ -0,0 +1,109 @@
+/*
+ * SPDX-FileCopyrightText: 2025 Christina Sørensen
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "░░░░"},
+		{50, "██░░"},
+		{62.5, "██▌░"},
+		{100, "████"},
+		{150, "████"},
+	}
+	for _, test := range tests {
+		filled, empty := progressBar(test.percent, 4)
+		if filled+empty != test.want {
+			t.Errorf("progressBar(%v): expected %q, got %q", test.percent, test.want, filled+empty)
+		}
+	}
+}
+
+func TestSleepBar(t *testing.T) {
+	at := func(date, clock string) time.Time { return atClock(testDate(date), testClock(clock)) }
+	tests := []struct {
+		bedtime, wake time.Time
+		want          string
+	}{
+		{at("2025-07-12", "23:00"), at("2025-07-13", "08:00"), "████████░░░░░░░░░░░░░░░█"},
+		{at("2025-07-13", "00:30"), at("2025-07-13", "09:30"), "▐████████▌░░░░░░░░░░░░░░"},
+		{at("2025-07-13", "13:00"), at("2025-07-13", "15:00"), "░░░░░░░░░░░░░██░░░░░░░░░"},
+	}
+	for _, test := range tests {
+		if got := sleepBar(test.bedtime, test.wake, 24); got != test.want {
+			t.Errorf("sleepBar(%s, %s): expected %q, got %q", test.bedtime.Format(timeFormat), test.wake.Format(timeFormat), test.want, got)
+		}
+	}
+}
+
+func TestCalendarView(t *testing.T) {
+	p := testPlan(t)
+	v := termView{width: 80, now: atClock(testDate("2025-07-14"), testClock("12:00"))}
+	var out bytes.Buffer
+	v.calendar(&out, p.Days())
+	want := `Jul 2025
+    Mon    Tue    Wed    Thu    Fri    Sat    Sun
+                                               13
+                                            10:00
+   [14]     15
+  09:00  08:00
+`
+	if out.String() != want {
+		t.Errorf("Unexpected calendar:\n%s\nexpected:\n%s", out.String(), want)
+	}
+
+	useLocale(t, newLocale("en_US"))
+	out.Reset()
+	v.color = true
+	v.calendar(&out, p.Days())
+	lines := strings.Split(out.String(), "\n")
+	if strings.Fields(lines[1])[0] != "Sun" || !strings.Contains(lines[2], stylePast) || !strings.Contains(lines[2], styleToday+"      [14]") {
+		t.Errorf("Expected a Sunday week with past days dimmed and today highlighted, got:\n%s", out.String())
+	}
+}
+
+func TestViewFitsWidth(t *testing.T) {
+	p := testPlan(t)
+	for _, width := range []int{40, 60, 80, 120} {
+		var out bytes.Buffer
+		v := termView{width: width, now: atClock(testDate("2025-07-14"), testClock("12:00"))}
+		v.writeView(&out, "calendar", p)
+		v.writeView(&out, "timeline", p)
+		for _, line := range strings.Split(out.String(), "\n") {
+			if utf8.RuneCountInString(line) > width {
+				t.Errorf("Line wider than %d columns: %q", width, line)
+			}
+		}
+	}
+}
+
+func TestPlanShowView(t *testing.T) {
+	setConfigDir(t.TempDir())
+	t.Setenv("COLUMNS", "72")
+	t.Setenv("NO_COLOR", "1")
+	timeNow = func() time.Time { return time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	runTest(t, "", "plan", "new", "07:00", "--target", "05:00", "--adjustment", "1h", "--start-date", "2025-01-01")
+
+	code, stdout, _ := runTest(t, "", "plan", "show", "-o", "timeline")
+	if code != 0 || !strings.Contains(stdout, "Progress toward 05:00") || !strings.Contains(stdout, "▸ Thu, Jan 2") || strings.Contains(stdout, "\x1b[") {
+		t.Errorf("Expected an uncoloured timeline with today marked, got %d:\n%s", code, stdout)
+	}
+	if _, stdout, _ := runTest(t, "", "plan", "show", "-o", "calendar"); !strings.Contains(stdout, "Jan 2025") || !strings.Contains(stdout, "[2]") {
+		t.Errorf("Expected a calendar, got:\n%s", stdout)
+	}
+}
@@ -47,5 +47,21 @@ pkgs.nixosTest {
     assert "Your sleep calibration plan:" in output
     assert "Wed, Jan 1 (Day 1):" in output
     assert "Wake up at 08:00" in output
+
+    # Test subcommands
+    machine.succeed("rm -f /root/.config/eepy/plan.json")
+    output = machine.succeed("eepy plan new 10:00 --target 09:00 --adjustment 30m --start-date 2025-01-01")
+    assert "Wake up at 09:30" in output
+    output = machine.succeed("eepy plan edit 2025-01-02 --wake 09:15")
+    assert "Wake up at 09:15 (edited)" in output
+    output = machine.succeed("eepy plan undo")
+    assert "Wake up at 09:30" in output
+    output = machine.succeed("eepy help")
+    assert "plan" in output
+
+    # Test non-interactive overrides
+    machine.fail("eepy plan new 08:00 --no-input")
+    output = machine.succeed("eepy plan new 08:00 --yes --start-date 2025-01-01")
+    assert "Wake up at 08:00" in output
 '';
 }